@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"strconv"
+	"strings"
 
 	"github.com/gregoryv/go-design/shape"
 )
@@ -11,8 +13,31 @@ import (
 type ClassDiagram struct {
 	Diagram
 
-	interfaces []VRecord
-	structs    []VRecord
+	// Detail controls how fields and methods of records added
+	// after it's set are written.
+	Detail shape.Detail
+
+	// Orthogonal routes relation arrows with horizontal and
+	// vertical segments around other records.
+	Orthogonal bool
+
+	// Spread distributes arrows meeting on the same side of a record
+	// evenly along it. Orthogonal arrows are not spread.
+	Spread bool
+
+	// DocURL is where documentation of types is found, records
+	// link to DocURL/import/path#Type. Empty disables the links.
+	DocURL string
+
+	interfaces  []VRecord
+	structs     []VRecord
+	generics    []VRecord
+	constraints []VRecord
+
+	// records with method dependencies shown
+	dependent map[reflect.Type]bool
+	// type parameters of generic records
+	params map[*shape.Record][]string
 }
 
 // NewClassDiagram returns a diagram representing structs and
@@ -20,38 +45,120 @@ type ClassDiagram struct {
 // arrows.
 func NewClassDiagram() *ClassDiagram {
 	return &ClassDiagram{
-		Diagram:    NewDiagram(),
-		interfaces: make([]VRecord, 0),
-		structs:    make([]VRecord, 0),
+		Diagram:     NewDiagram(),
+		DocURL:      "https://pkg.go.dev",
+		interfaces:  make([]VRecord, 0),
+		structs:     make([]VRecord, 0),
+		generics:    make([]VRecord, 0),
+		constraints: make([]VRecord, 0),
+		dependent:   make(map[reflect.Type]bool),
+		params:      make(map[*shape.Record][]string),
 	}
 }
 
 func (d *ClassDiagram) Interface(obj interface{}) VRecord {
-	vr := NewInterface(obj)
+	vr := NewInterface(obj, d.Detail)
 	d.interfaces = append(d.interfaces, vr)
 	return vr
 }
 
 func (d *ClassDiagram) Struct(obj interface{}) VRecord {
-	vr := NewStruct(obj)
+	vr := NewStruct(obj, d.Detail)
 	d.structs = append(d.structs, vr)
 	return vr
 }
 
+// Generic adds a record of a generic type declaration based on the
+// instantiation obj. Params are the type parameters in declaration
+// order, e.g. "K comparable", "V any". Visible instantiations are
+// linked to the generic record.
+func (d *ClassDiagram) Generic(obj interface{}, params ...string) VRecord {
+	vr := NewGeneric(obj, params, d.Detail)
+	d.generics = append(d.generics, vr)
+	d.params[vr.Record] = params
+	return vr
+}
+
+// Constraint adds an interface record of a type constraint with the
+// given type set terms, e.g. "~int", "~float64". Generic records
+// with parameters constrained by name are linked to it.
+func (d *ClassDiagram) Constraint(name string, terms ...string) VRecord {
+	vr := VRecord{
+		Record: shape.NewConstraintRecord(name, terms...),
+		name:   name,
+	}
+	d.constraints = append(d.constraints, vr)
+	return vr
+}
+
 // WriteSvg renders the diagram as SVG to the given writer.
 func (d *ClassDiagram) WriteSvg(w io.Writer) error {
+	// relations are drawn between the solved positions
+	if err := d.solve(); err != nil {
+		return err
+	}
 	rel := d.implements()
 	rel = append(rel, d.composes()...)
+	rel = append(rel, d.embeds()...)
+	rel = append(rel, d.extends()...)
+	rel = append(rel, d.uses()...)
+	rel = append(rel, d.depends()...)
+	rel = append(rel, d.binds()...)
+	rel = append(rel, d.constrains()...)
+	for _, s := range rel {
+		d.applyStyle(s)
+	}
+	if d.Spread {
+		shape.Spread(arrows(rel)...)
+	}
 	d.Diagram.Prepend(rel...)
+	d.linkDocs()
 	return d.Diagram.WriteSvg(w)
 }
 
+// linkDocs links records without a url to the documentation of
+// their type.
+func (d *ClassDiagram) linkDocs() {
+	if d.DocURL == "" {
+		return
+	}
+	for _, vr := range append(d.records(), d.generics...) {
+		if vr.URL == "" {
+			vr.SetURL(vr.docURL(d.DocURL))
+		}
+	}
+}
+
+// arrows returns the arrows among the shapes.
+func arrows(s []shape.Shape) []*shape.Arrow {
+	res := make([]*shape.Arrow, 0)
+	for _, s := range s {
+		if a, ok := s.(*shape.Arrow); ok {
+			res = append(res, a)
+		}
+	}
+	return res
+}
+
+// arrowBetween returns an arrow between the records, routed around
+// other shapes if the diagram is orthogonal.
+func (d *ClassDiagram) arrowBetween(from, to VRecord) *shape.Arrow {
+	arrow := shape.NewArrowBetween(from, to)
+	if d.Orthogonal {
+		d.Router().Route(arrow)
+	}
+	return arrow
+}
+
+// implements returns arrows from structs to the interfaces they
+// implement. Interfaces already implied by a more specific visible
+// interface are left out.
 func (d *ClassDiagram) implements() []shape.Shape {
 	rel := make([]shape.Shape, 0)
 	for _, struct_ := range d.structs {
 		for _, iface := range d.interfaces {
-			if reflect.PtrTo(struct_.t).Implements(iface.t) {
-				arrow := shape.NewArrowBetween(struct_, iface)
+			if d.implemented(reflect.PtrTo(struct_.t), iface) {
+				arrow := d.arrowBetween(struct_, iface)
 				arrow.SetClass("implements-arrow")
 				arrow.Head.SetClass("implements-arrow-head")
 				rel = append(rel, arrow)
@@ -61,17 +168,321 @@ func (d *ClassDiagram) implements() []shape.Shape {
 	return rel
 }
 
+// implemented returns true if t implements iface and no other
+// interface implemented by t extends iface.
+func (d *ClassDiagram) implemented(t reflect.Type, iface VRecord) bool {
+	if !t.Implements(iface.t) {
+		return false
+	}
+	for _, other := range d.interfaces {
+		if other.t != t && t.Implements(other.t) && extends(other.t, iface.t) {
+			return false
+		}
+	}
+	return true
+}
+
+// extends returns arrows between interfaces that extend other
+// visible interfaces, e.g. io.ReadWriter extends io.Reader.
+func (d *ClassDiagram) extends() []shape.Shape {
+	rel := make([]shape.Shape, 0)
+	for _, a := range d.interfaces {
+		for _, b := range d.interfaces {
+			if a.t != b.t && d.implemented(a.t, b) {
+				arrow := d.arrowBetween(a, b)
+				arrow.SetClass("extends-arrow")
+				rel = append(rel, arrow)
+			}
+		}
+	}
+	return rel
+}
+
+// extends returns true if interface a has all methods of b and
+// more.
+func extends(a, b reflect.Type) bool {
+	return a != b && a.Implements(b) && a.NumMethod() > b.NumMethod()
+}
+
+// uses returns dependency arrows from records to visible interfaces
+// used as method parameters. Records already related, e.g. by
+// implementing the interface, are not linked again.
+func (d *ClassDiagram) uses() []shape.Shape {
+	rel := make([]shape.Shape, 0)
+	for _, from := range d.records() {
+		for _, iface := range d.interfaces {
+			if from.t == iface.t || d.related(from, iface) {
+				continue
+			}
+			if !inSignatures(from.methodType(), iface.t, false) {
+				continue
+			}
+			arrow := d.arrowBetween(from, iface)
+			arrow.SetClass("uses-arrow")
+			rel = append(rel, arrow)
+		}
+	}
+	return rel
+}
+
+// related returns true if a implements, extends or has a field
+// referring to b.
+func (d *ClassDiagram) related(a, b VRecord) bool {
+	if b.isStruct && !a.isStruct {
+		return false
+	}
+	if a.isStruct {
+		if !b.isStruct && reflect.PtrTo(a.t).Implements(b.t) {
+			return true
+		}
+		for i := 0; i < a.t.NumField(); i++ {
+			t, _, _ := fieldTarget(a.t.Field(i).Type)
+			if t == b.t {
+				return true
+			}
+		}
+		return false
+	}
+	return extends(a.t, b.t)
+}
+
+// ShowDependencies draws dashed arrows from the given records to
+// other records found in their method parameters or results.
+func (d *ClassDiagram) ShowDependencies(records ...VRecord) {
+	for _, vr := range records {
+		d.dependent[vr.t] = true
+	}
+}
+
+// depends returns dependency arrows for records with dependencies
+// shown. Records already related or using an interface are not
+// linked again.
+func (d *ClassDiagram) depends() []shape.Shape {
+	rel := make([]shape.Shape, 0)
+	for _, from := range d.records() {
+		if !d.dependent[from.t] {
+			continue
+		}
+		for _, to := range d.records() {
+			if from.t == to.t || d.related(from, to) {
+				continue
+			}
+			t := from.methodType()
+			if !to.isStruct && inSignatures(t, to.t, false) {
+				continue // drawn by uses
+			}
+			if !inSignatures(t, to.t, true) {
+				continue
+			}
+			arrow := d.arrowBetween(from, to)
+			arrow.SetClass("dependency-arrow")
+			rel = append(rel, arrow)
+		}
+	}
+	return rel
+}
+
+// inSignatures returns true if any method of t has a parameter, or
+// optionally a result, referring to the type p.
+func inSignatures(t, p reflect.Type, results bool) bool {
+	var skip int
+	if t.Kind() != reflect.Interface {
+		skip = 1 // receiver
+	}
+	for i := 0; i < t.NumMethod(); i++ {
+		m := t.Method(i).Type
+		for j := skip; j < m.NumIn(); j++ {
+			if in, _, _ := fieldTarget(m.In(j)); in == p {
+				return true
+			}
+		}
+		if !results {
+			continue
+		}
+		for j := 0; j < m.NumOut(); j++ {
+			if out, _, _ := fieldTarget(m.Out(j)); out == p {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// composes returns arrows for fields referring to other records on
+// the diagram. Struct values are compositions, pointers are
+// aggregations and interfaces associations. Slices and maps result in
+// many and arrays in their length, which is shown as multiplicity at
+// the arrow end.
 func (d *ClassDiagram) composes() []shape.Shape {
 	rel := make([]shape.Shape, 0)
 	for _, struct_ := range d.structs {
 		for i := 0; i < struct_.t.NumField(); i++ {
 			field := struct_.t.Field(i)
-			for _, struct2 := range d.structs {
-				if field.Type == struct2.t {
-					arrow := shape.NewArrowBetween(struct_, struct2)
+			if field.Anonymous && struct_.embeds {
+				continue // drawn as embeds
+			}
+			t, many, ptr := fieldTarget(field.Type)
+			if t == struct_.t {
+				continue // e.g. next node in a linked list
+			}
+			for _, other := range d.records() {
+				if t != other.t {
+					continue
+				}
+				arrow := d.arrowBetween(struct_, other)
+				switch {
+				case !other.isStruct:
+					arrow.SetClass("association-arrow")
+				case ptr:
+					arrow.Tail = shape.NewDiamond()
+					arrow.SetClass("aggregate-arrow")
+				default:
 					arrow.Tail = shape.NewDiamond()
 					arrow.SetClass("compose-arrow")
 					arrow.Tail.SetClass("compose-arrow-tail")
+				}
+				role := arrow.AddLabel(shape.AtEnd, field.Name)
+				role.SetClass("role")
+				d.multiplicity(arrow, many, ptr)
+				rel = append(rel, arrow)
+			}
+		}
+	}
+	return rel
+}
+
+// fieldTarget returns the type a field of type t refers to and if
+// it refers to many or optionally one. Many is the multiplicity, * or
+// the length of an array, empty if t refers to one.
+func fieldTarget(t reflect.Type) (target reflect.Type, many string, ptr bool) {
+	switch t.Kind() {
+	case reflect.Slice, reflect.Map:
+		many = "*"
+		t = t.Elem()
+	case reflect.Array:
+		many = strconv.Itoa(t.Len())
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Ptr {
+		ptr = true
+		t = t.Elem()
+	}
+	return t, many, ptr
+}
+
+// multiplicity adds a label below the end of the arrow.
+func (d *ClassDiagram) multiplicity(arrow *shape.Arrow, many string, ptr bool) {
+	txt := "1"
+	switch {
+	case many != "":
+		txt = many
+	case ptr:
+		txt = "0..1"
+	}
+	label := arrow.AddLabel(shape.AtEnd, txt)
+	label.SetClass("multiplicity")
+	label.Below = true
+}
+
+// binds returns arrows from instantiated generic types to their
+// generic declaration labeled with the type arguments.
+func (d *ClassDiagram) binds() []shape.Shape {
+	rel := make([]shape.Shape, 0)
+	for _, g := range d.generics {
+		for _, inst := range d.records() {
+			if !instanceOf(inst.t, g.t) {
+				continue
+			}
+			args := d.Detail.TypeArgs(inst.t)
+			bind := make([]string, len(args))
+			for i, arg := range args {
+				bind[i] = strings.Fields(d.params[g.Record][i])[0] + "→" + arg
+			}
+			arrow := d.arrowBetween(inst, g)
+			arrow.SetClass("bind-arrow")
+			txt := "«bind» " + strings.Join(bind, ", ")
+			arrow.AddLabel(shape.AtMiddle, txt)
+			rel = append(rel, arrow)
+		}
+	}
+	return rel
+}
+
+// instanceOf returns true if a is another instantiation of the same
+// generic type as b.
+func instanceOf(a, b reflect.Type) bool {
+	return a != b && a.PkgPath() == b.PkgPath() &&
+		strings.Contains(a.Name(), "[") &&
+		shape.GenericName(a) == shape.GenericName(b)
+}
+
+// constrains returns arrows from generic records to constraints and
+// interfaces named in their type parameters.
+func (d *ClassDiagram) constrains() []shape.Shape {
+	rel := make([]shape.Shape, 0)
+	to := make([]VRecord, 0)
+	to = append(to, d.constraints...)
+	to = append(to, d.interfaces...)
+	for _, g := range d.generics {
+		for _, c := range to {
+			if !constrainedBy(d.params[g.Record], c.typeName()) {
+				continue
+			}
+			arrow := d.arrowBetween(g, c)
+			arrow.SetClass("uses-arrow")
+			rel = append(rel, arrow)
+		}
+	}
+	return rel
+}
+
+// constrainedBy returns true if any of the type parameters uses name
+// in its constraint.
+func constrainedBy(params []string, name string) bool {
+	for _, p := range params {
+		words := strings.FieldsFunc(p, func(r rune) bool {
+			return strings.ContainsRune(" |~[],", r)
+		})
+		if len(words) < 2 {
+			continue // unconstrained or malformed
+		}
+		for _, w := range words[1:] {
+			if w == name || strings.HasSuffix(w, "."+name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// records returns all structs and interfaces of the diagram.
+func (d *ClassDiagram) records() []VRecord {
+	all := make([]VRecord, 0, len(d.structs)+len(d.interfaces))
+	all = append(all, d.structs...)
+	return append(all, d.interfaces...)
+}
+
+// embeds returns arrows from structs to the types they embed if
+// embedded fields are detailed for the struct.
+func (d *ClassDiagram) embeds() []shape.Shape {
+	rel := make([]shape.Shape, 0)
+	for _, struct_ := range d.structs {
+		if !struct_.embeds {
+			continue
+		}
+		for i := 0; i < struct_.t.NumField(); i++ {
+			field := struct_.t.Field(i)
+			if !field.Anonymous {
+				continue
+			}
+			t := field.Type
+			if t.Kind() == reflect.Ptr {
+				t = t.Elem()
+			}
+			for _, other := range d.records() {
+				if t == other.t {
+					arrow := d.arrowBetween(struct_, other)
+					arrow.SetClass("embeds-arrow")
 					rel = append(rel, arrow)
 				}
 			}
@@ -120,14 +531,15 @@ type Relation struct {
 
 // NewStruct returns a VRecord of the given object, panics if not
 // struct.
-func NewStruct(obj interface{}) VRecord {
+func NewStruct(obj interface{}, detail ...shape.Detail) VRecord {
 	t := reflect.TypeOf(obj)
 	if t.Kind() != reflect.Struct {
 		panic(fmt.Sprintf("Expected struct kind got %v", t.Kind()))
 	}
 	return VRecord{
-		Record:   shape.NewStructRecord(obj),
+		Record:   shape.NewStructRecord(obj, detail...),
 		t:        t,
 		isStruct: true,
+		embeds:   embedsFields(detail),
 	}
 }
@@ -0,0 +1,63 @@
+//go:build go1.18
+
+package design
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gregoryv/asserter"
+)
+
+type number interface {
+	~int | ~float64
+}
+
+type stack[T any] struct {
+	Items []T
+}
+
+func (s *stack[T]) Push(v T) {}
+
+type sum[T number] struct {
+	Total T
+}
+
+func TestClassDiagram_Generic(t *testing.T) {
+	d := NewClassDiagram()
+	d.Detail.Types = true
+	d.Detail.Signatures = true
+	d.Detail.Pkg = "github.com/gregoryv/go-design"
+	s := d.Generic(stack[any]{}, "T any")
+	d.Struct(stack[int]{})
+	d.Struct(stack[*wheel]{})
+	sm := d.Generic(sum[float64]{}, "T number")
+	d.Constraint("number", "~int", "~float64")
+	assert := asserter.New(t)
+	assert().Equals(s.Title, "stack[T any] struct")
+	assert().Equals(strings.Join(s.Fields, ","), "Items []T")
+	assert().Equals(strings.Join(s.Methods, ","), "Push(T)")
+	assert().Equals(strings.Join(sm.Fields, ","), "Total T")
+
+	var labels []string
+	for _, a := range arrows(d.binds()) {
+		for _, l := range a.Labels {
+			labels = append(labels, l.Text)
+		}
+	}
+	assert().Equals(
+		strings.Join(labels, ","),
+		"«bind» T→int,«bind» T→*wheel",
+	)
+	got := len(d.constrains())
+	assert(got == 1).Errorf("got %v constraint arrows", got)
+
+	assert(!constrainedBy([]string{"", "|"}, "number")).Error("malformed parameters constrained")
+
+	func() {
+		defer mustCatchPanic(t)
+		NewGeneric(stack[any]{}, []string{""})
+	}()
+	defer mustCatchPanic(t)
+	NewGeneric(stack[any]{}, []string{"K", "V"})
+}
@@ -1,10 +1,13 @@
 package design
 
 import (
+	"bytes"
 	"io"
+	"strings"
 	"testing"
 
 	"github.com/gregoryv/asserter"
+	"github.com/gregoryv/go-design/shape"
 )
 
 func TestDesignPackage(t *testing.T) {
@@ -46,3 +49,177 @@ func mustCatchPanic(t asserter.T) {
 		t.Error("should panic")
 	}
 }
+
+func TestClassDiagram_Detail(t *testing.T) {
+	d := NewClassDiagram()
+	d.Detail = shape.Detail{Signatures: true, Pkg: "io"}
+	w := d.Interface((*io.Writer)(nil))
+	assert := asserter.New(t)
+	assert().Equals(w.Methods[0], "Write([]byte) (int, error)")
+}
+
+func TestClassDiagram_embeds(t *testing.T) {
+	d := NewClassDiagram()
+	d.Struct(Diagram{})
+	d.Struct(shape.Svg{})
+	assert := asserter.New(t)
+	assert(len(d.embeds()) == 0).Error("embeds drawn without detail")
+	assert(len(arrows(d.composes())) == 1).Error("expected composition")
+
+	d = NewClassDiagram()
+	d.Detail.Embedded = true
+	d.Struct(Diagram{})
+	d.Struct(shape.Svg{})
+	assert(len(d.embeds()) == 1).Error("expected embeds arrow")
+	assert(len(arrows(d.composes())) == 0).Error("embedded drawn as composition")
+
+	d.Detail.Embedded = false
+	assert(len(d.embeds()) == 1).Error("embeds follow detail changed after adding")
+	assert(len(arrows(d.composes())) == 0).Error("composition follows detail changed after adding")
+}
+
+type car struct {
+	Engine  engine
+	Driver  *driver
+	Wheels  []wheel
+	Spare   *wheel
+	Lights  map[string]*light
+	Radio   io.Reader
+	Windows [4]wheel
+}
+
+type engine struct{}
+type driver struct{}
+type wheel struct{}
+type light struct{}
+
+func TestClassDiagram_composes(t *testing.T) {
+	d := NewClassDiagram()
+	d.Struct(car{})
+	d.Struct(engine{})
+	d.Struct(driver{})
+	d.Struct(wheel{})
+	d.Struct(light{})
+	d.Interface((*io.Reader)(nil))
+	got := make(map[string]int)
+	for _, s := range d.composes() {
+		switch s := s.(type) {
+		case *shape.Arrow:
+			buf := &bytes.Buffer{}
+			s.WriteSvg(buf)
+			class := strings.Split(buf.String(), `"`)[1]
+			got[class]++
+			for _, l := range s.Labels {
+				if l.Below {
+					got[l.Text]++
+				} else {
+					got["role "+l.Text]++
+				}
+			}
+		}
+	}
+	exp := map[string]int{
+		"compose-arrow":     3,
+		"aggregate-arrow":   3,
+		"association-arrow": 1,
+		"1":                 2,
+		"0..1":              2,
+		"*":                 2,
+		"4":                 1,
+		"role Engine":       1,
+		"role Wheels":       1,
+	}
+	assert := asserter.New(t)
+	for k, v := range exp {
+		assert(got[k] == v).Errorf("%s: got %v, expected %v", k, got[k], v)
+	}
+}
+
+type listNode struct {
+	Value int
+	Next  *listNode
+}
+
+func TestClassDiagram_composesSelf(t *testing.T) {
+	d := NewClassDiagram()
+	d.Struct(listNode{})
+	assert := asserter.New(t)
+	assert(len(d.composes()) == 0).Error("self reference drawn")
+}
+
+type rw struct{}
+
+func (rw) Read([]byte) (int, error)  { return 0, nil }
+func (rw) Write([]byte) (int, error) { return 0, nil }
+
+type copier struct{}
+
+func (*copier) Copy(dst io.Writer, src ...io.Reader) {}
+
+func TestClassDiagram_extends(t *testing.T) {
+	d := NewClassDiagram()
+	d.Interface((*io.ReadWriter)(nil))
+	d.Interface((*io.Reader)(nil))
+	d.Interface((*io.Writer)(nil))
+	d.Interface((*io.ReadWriteCloser)(nil))
+	d.Struct(rw{})
+	assert := asserter.New(t)
+	got := len(d.extends())
+	// ReadWriteCloser -> ReadWriter -> Reader, Writer
+	assert(got == 3).Errorf("got %v extends arrows", got)
+	got = len(d.implements())
+	assert(got == 1).Errorf("got %v implements arrows", got)
+}
+
+func TestClassDiagram_uses(t *testing.T) {
+	d := NewClassDiagram()
+	d.Interface((*io.Reader)(nil))
+	d.Interface((*io.Writer)(nil))
+	d.Struct(copier{})
+	d.Struct(rw{})
+	assert := asserter.New(t)
+	// rw implements both, only copier uses them
+	got := len(d.uses())
+	assert(got == 2).Errorf("got %v uses arrows", got)
+}
+
+type factory struct{}
+
+func (*factory) Assemble(e *engine, w ...wheel) car { return car{} }
+
+func TestClassDiagram_ShowDependencies(t *testing.T) {
+	d := NewClassDiagram()
+	f := d.Struct(factory{})
+	d.Struct(engine{})
+	d.Struct(wheel{})
+	d.Struct(car{})
+	assert := asserter.New(t)
+	got := len(d.depends())
+	assert(got == 0).Errorf("got %v dependencies when not shown", got)
+
+	d.ShowDependencies(f)
+	got = len(d.depends())
+	assert(got == 3).Errorf("got %v dependency arrows", got)
+}
+
+func TestClassDiagram_DocURL(t *testing.T) {
+	d := NewClassDiagram()
+	r := d.Struct(Diagram{})
+	s := d.Struct(car{})
+	c := d.Constraint("number", "~int")
+	custom := d.Interface((*io.Writer)(nil))
+	custom.SetURL("https://example.com")
+	d.WriteSvg(io.Discard)
+
+	assert := asserter.New(t)
+	assert().Equals(r.URL, "https://pkg.go.dev/github.com/gregoryv/go-design#Diagram")
+	assert().Equals(s.URL, "") // unexported
+	assert().Equals(c.URL, "")
+	assert().Equals(custom.URL, "https://example.com")
+
+	d = NewClassDiagram()
+	d.DocURL = ""
+	r = d.Struct(Diagram{})
+	d.WriteSvg(io.Discard)
+	assert().Equals(r.URL, "")
+}
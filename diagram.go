@@ -4,6 +4,7 @@ import (
 	"io"
 
 	"github.com/gregoryv/go-design/shape"
+	"github.com/gregoryv/go-design/xy"
 )
 
 // NewDiagram returns a diagram with present font and padding values.
@@ -23,6 +24,43 @@ type Diagram struct {
 	shape.Style
 
 	Caption *shape.Label
+
+	constraints *shape.Constraints
+}
+
+// Constrain returns the placement constraints of the diagram. They
+// are solved when the diagram is written.
+func (d *Diagram) Constrain() *shape.Constraints {
+	if d.constraints == nil {
+		d.constraints = shape.NewConstraints()
+	}
+	return d.constraints
+}
+
+// solve places shapes according to the constraints if any and
+// reconnects arrows to the moved shapes.
+func (d *Diagram) solve() error {
+	if d.constraints == nil {
+		return nil
+	}
+	if err := d.constraints.Solve(); err != nil {
+		return err
+	}
+	d.reconnect()
+	return nil
+}
+
+// reconnect moves arrows between shapes to the current edges of the
+// shapes, routed arrows are routed again.
+func (d *Diagram) reconnect() {
+	router := d.Router()
+	for _, arrow := range d.arrows() {
+		if arrow.Routed() {
+			router.Route(arrow)
+			continue
+		}
+		arrow.Reconnect()
+	}
 }
 
 // Place adds the shape to the diagram returning an adjuster for
@@ -36,42 +74,21 @@ func (diagram *Diagram) Place(s ...shape.Shape) *shape.Adjuster {
 }
 
 // PlaceGrid place all the shapes into a grid starting at X,Y
-// position. Row height is adapted to heighest element.
+// position. Column width is adapted to the widest and row height to
+// the heighest element. Shapes are centered in their columns.
 func (diagram *Diagram) PlaceGrid(cols, X, Y int, s ...shape.Shape) {
-	row := make([]shape.Shape, cols)
-	var x, y int
-	var h shape.Shape
-	for i, s := range s {
-		switch {
-		case i == 0:
-			diagram.Place(s).At(X, Y)
-		case y == 0:
-			diagram.Place(s).RightOf(row[x-1])
-			//			diagram.HAlignCenter(row[x-1], s)
-		default:
-			diagram.Place(s).Below(h)
-			diagram.VAlignCenter(row[x], s)
-		}
-		row[x] = s
-		x++
-		if x == cols {
-			x = 0
-			y++
-			h = highest(row...)
-		}
-	}
+	grid := shape.NewGrid(cols)
+	grid.X, grid.Y = X, Y
+	grid.HAlign = shape.Center
+	grid.Add(s...)
+	diagram.PlaceInGrid(grid)
 }
 
-func highest(s ...shape.Shape) shape.Shape {
-	var h int
-	var r shape.Shape
-	for _, s := range s {
-		if s.Height() > h {
-			h = s.Height()
-			r = s
-		}
-	}
-	return r
+// PlaceInGrid adds the shapes of the grid to the diagram and moves
+// them into their cells.
+func (diagram *Diagram) PlaceInGrid(grid *shape.Grid) {
+	diagram.Place(grid.Shapes()...)
+	grid.Place()
 }
 
 // LinkAll places an arrow between the shapes, s0->s1->...->sn
@@ -81,15 +98,78 @@ func (diagram *Diagram) LinkAll(s ...shape.Shape) {
 	}
 }
 
-func (diagram *Diagram) Link(from, to shape.Shape, txt string) {
+// LinkOrthogonal places an arrow between the shapes made of
+// horizontal and vertical segments avoiding other shapes on the
+// diagram.
+func (diagram *Diagram) LinkOrthogonal(from, to shape.Shape) *shape.Arrow {
+	arrow := shape.NewArrowBetween(from, to)
+	diagram.Router().Route(arrow)
+	diagram.Place(arrow)
+	return arrow
+}
+
+// LinkVia places an arrow between the shapes passing the given
+// waypoints.
+func (diagram *Diagram) LinkVia(from, to shape.Shape, waypoints ...xy.Position) *shape.Arrow {
+	arrow := shape.NewArrowVia(from, to, waypoints...)
+	diagram.Place(arrow)
+	return arrow
+}
+
+// LinkCurved places a curved arrow between the shapes bending
+// sideways by bend pixels. Use different bends to separate parallel
+// links between the same shapes.
+func (diagram *Diagram) LinkCurved(from, to shape.Shape, bend int) *shape.Arrow {
+	arrow := shape.NewCurvedArrow(from, to, bend)
+	diagram.Place(arrow)
+	return arrow
+}
+
+// LinkPorts places an arrow from port fp of shape from to port tp
+// of shape to, e.g.
+//
+//	d.LinkPorts(a, shape.S, b, shape.PortAt(shape.North, 0.25))
+func (diagram *Diagram) LinkPorts(from shape.Shape, fp shape.Port, to shape.Shape, tp shape.Port) *shape.Arrow {
+	arrow := shape.NewArrowPorts(from, fp, to, tp)
+	diagram.Place(arrow)
+	return arrow
+}
+
+// SpreadArrows distributes arrows meeting on the same side of a
+// shape evenly along that side.
+func (diagram *Diagram) SpreadArrows() {
+	arrows := make([]*shape.Arrow, 0)
+	for _, s := range diagram.Content {
+		if a, ok := s.(*shape.Arrow); ok {
+			arrows = append(arrows, a)
+		}
+	}
+	shape.Spread(arrows...)
+}
+
+// Link places an arrow between the shapes with a label in the middle.
+func (diagram *Diagram) Link(from, to shape.Shape, txt string) *shape.Arrow {
 	lnk := shape.NewArrowBetween(from, to)
+	lnk.AddLabel(shape.AtMiddle, txt)
 	diagram.Place(lnk)
-	label := shape.NewLabel(txt)
-	diagram.Place(label).Above(lnk, 20)
-	diagram.VAlignCenter(lnk, label)
+	return lnk
 }
 
 func (diagram *Diagram) applyStyle(s interface{}) {
+	switch s := s.(type) {
+	case *shape.Arrow:
+		for _, l := range s.Labels {
+			diagram.applyStyle(l.Label)
+		}
+	case *shape.Group:
+		for _, c := range s.Children {
+			diagram.applyStyle(c)
+		}
+	case *shape.Container:
+		for _, c := range s.Children {
+			diagram.applyStyle(c)
+		}
+	}
 	if s, ok := s.(shape.HasFont); ok {
 		s.SetFont(diagram.Font)
 	}
@@ -104,6 +184,9 @@ func (d *Diagram) SaveAs(filename string) error {
 }
 
 func (d *Diagram) WriteSvg(w io.Writer) error {
+	if err := d.solve(); err != nil {
+		return err
+	}
 	if d.Width == 0 && d.Height == 0 {
 		d.AdaptSize()
 	}
@@ -117,34 +200,52 @@ func (d *Diagram) WriteSvg(w io.Writer) error {
 		d.AdaptSize()
 		d.Height += d.Caption.Font.Height / 2 // Fit protruding letters like 'g'
 	}
-	return d.Svg.WriteSvg(w)
+	svg := d.Svg
+	if svg.Title == "" && d.Caption != nil {
+		svg.Title = d.Caption.Text
+	}
+	return svg.WriteSvg(w)
 }
 
 // AdaptSize adapts the diagram size to the shapes inside it so all
-// are visible. Returns the new width and height
+// are visible, including arrow labels. Returns the new width and
+// height
 func (diagram *Diagram) AdaptSize() (int, int) {
 	for _, s := range diagram.Content {
-		x, y := s.Position()
-		switch s := s.(type) {
-		case *shape.Line:
-			x = min(s.Start.X, s.End.X)
-			y = min(s.Start.Y, s.End.Y)
-		case *shape.Arrow:
-			x = min(s.Start.X, s.End.X)
-			y = min(s.Start.Y, s.End.Y)
-		}
-		w := x + s.Width()
-		if w > diagram.Width {
-			diagram.Width = w
-		}
-		h := y + s.Height()
-		if h > diagram.Height {
-			diagram.Height = h
+		diagram.fit(s)
+		if arrow, ok := s.(*shape.Arrow); ok {
+			arrow.PlaceLabels()
+			for _, l := range arrow.Labels {
+				diagram.fit(l)
+			}
 		}
 	}
 	return diagram.Width, diagram.Height
 }
 
+// fit grows the diagram so s is visible.
+func (diagram *Diagram) fit(s shape.Shape) {
+	x, y := s.Position()
+	switch s := s.(type) {
+	case *shape.Line:
+		x = min(s.Start.X, s.End.X)
+		y = min(s.Start.Y, s.End.Y)
+	case *shape.Arrow:
+		for _, p := range s.Points() {
+			x = min(x, p.X)
+			y = min(y, p.Y)
+		}
+	}
+	w := x + s.Width()
+	if w > diagram.Width {
+		diagram.Width = w
+	}
+	h := y + s.Height()
+	if h > diagram.Height {
+		diagram.Height = h
+	}
+}
+
 func min(a, b int) int {
 	if a < b {
 		return a
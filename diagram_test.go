@@ -2,10 +2,12 @@ package design
 
 import (
 	"bytes"
+	"strings"
 	"testing"
 
 	"github.com/gregoryv/asserter"
 	"github.com/gregoryv/go-design/shape"
+	"github.com/gregoryv/go-design/xy"
 )
 
 func TestDiagram(t *testing.T) {
@@ -60,3 +62,159 @@ func TestDiagram_PlaceGrid(t *testing.T) {
 	d.Width += 10
 	d.SaveAs("img/grid_layout.svg")
 }
+
+func TestDiagram_LinkOrthogonal(t *testing.T) {
+	var (
+		d     = NewDiagram()
+		a     = shape.NewRect("a")
+		b     = shape.NewRect("b")
+		block = shape.NewRect("between")
+	)
+	d.Place(a).At(10, 10)
+	d.Place(block).RightOf(a)
+	d.Place(b).RightOf(block)
+	arrow := d.LinkOrthogonal(a, b)
+	assert := asserter.New(t)
+	assert(len(arrow.Waypoints) > 0).Error("arrow not routed around shape")
+	assert(d.Content[len(d.Content)-1] == arrow).Error("arrow not placed")
+}
+
+func TestDiagram_LinkVia(t *testing.T) {
+	var (
+		d   = NewDiagram()
+		a   = shape.NewRect("a")
+		b   = shape.NewRect("b")
+		via = xy.Position{X: 100, Y: 10}
+	)
+	d.Place(a).At(10, 10)
+	d.Place(b).At(100, 100)
+	arrow := d.LinkVia(a, b, via)
+	assert := asserter.New(t)
+	assert(len(arrow.Waypoints) == 1).Error("missing waypoint")
+	assert(arrow.End.Y == 100).Errorf("end %v not on top of b", arrow.End)
+}
+
+func TestDiagram_LinkCurved(t *testing.T) {
+	var (
+		d = NewDiagram()
+		a = shape.NewRect("a")
+		b = shape.NewRect("b")
+	)
+	d.Place(a).At(10, 10)
+	d.Place(b).At(200, 10)
+	there := d.LinkCurved(a, b, 20)
+	back := d.LinkCurved(b, a, 20)
+	assert := asserter.New(t)
+	assert(there.Curved && back.Curved).Error("not curved")
+	assert(there.Waypoints[0].Y != back.Waypoints[0].Y).Error(
+		"parallel links overlap",
+	)
+}
+
+func TestDiagram_SpreadArrows(t *testing.T) {
+	var (
+		d = NewDiagram()
+		a = shape.NewRect("a")
+		b = shape.NewRect("b")
+		c = shape.NewRect("c")
+	)
+	d.Place(a).At(100, 10)
+	d.Place(b).At(10, 100)
+	d.Place(c).At(130, 100)
+	ab := d.LinkPorts(a, shape.S, b, shape.N)
+	d.LinkAll(c, a)
+	d.SpreadArrows()
+	assert := asserter.New(t)
+	assert(ab.End.Equals(shape.N.Position(b))).Error("single end moved")
+	assert(!ab.Start.Equals(shape.S.Position(a))).Error("start not spread")
+}
+
+func TestDiagram_Constrain(t *testing.T) {
+	var (
+		d = NewDiagram()
+		a = shape.NewRect("a")
+		b = shape.NewRect("b")
+	)
+	d.Place(a, b)
+	d.Constrain().Below(b, a, 10)
+	d.Constrain().VAlignLeft(a, b)
+	d.Constrain().At(a, 20, 20)
+	assert := asserter.New(t)
+	err := d.WriteSvg(&bytes.Buffer{})
+	assert(err == nil).Fatal(err)
+	x, y := b.Position()
+	assert(x == 20 && y == 20+a.Height()+10).Errorf("b at %v,%v", x, y)
+
+	d.Constrain().At(b, 0, 0)
+	err = d.WriteSvg(&bytes.Buffer{})
+	assert(err != nil).Error("conflict not reported")
+}
+
+func TestDiagram_Constrain_arrows(t *testing.T) {
+	var (
+		d = NewDiagram()
+		a = shape.NewRect("a")
+		b = shape.NewRect("b")
+		c = shape.NewRect("c")
+	)
+	d.Place(a, b, c)
+	arrow := d.Link(a, b, "x")
+	routed := d.LinkOrthogonal(a, c)
+	d.Constrain().At(a, 20, 20)
+	d.Constrain().Below(b, a, 40)
+	d.Constrain().RightOf(c, a, 40)
+	assert := asserter.New(t)
+	err := d.WriteSvg(&bytes.Buffer{})
+	assert(err == nil).Fatal(err)
+	_, by := b.Position()
+	assert(arrow.Start.Y == 20+a.Height()).Errorf("start %v", arrow.Start)
+	assert(arrow.End.Y == by).Errorf("end %v", arrow.End)
+	cx, _ := c.Position()
+	assert(routed.End.X == cx).Errorf("routed end %v", routed.End)
+}
+
+func TestDiagram_Meta(t *testing.T) {
+	d := NewDiagram()
+	d.Meta = true
+	a, b := shape.NewRect("a"), shape.NewState("b")
+	d.Place(a).At(10, 10)
+	d.Place(b).RightOf(a)
+	d.Link(a, b, "goes to")
+	var buf bytes.Buffer
+	d.Style.SetOutput(&buf)
+	err := d.WriteSvg(&d.Style)
+	assert := asserter.New(t)
+	assert(err == nil).Fatal(err)
+
+	svg, err := shape.ParseSvg(&buf)
+	assert(err == nil).Fatal(err)
+	assert(len(svg.Content) == 3).Fatal(svg.Content)
+	loaded := NewDiagram()
+	loaded.Place(svg.Content...)
+	x, y := svg.Content[1].Position()
+	bx, by := b.Position()
+	assert(x == bx && y == by).Errorf("b at %v,%v, expected %v,%v", x, y, bx, by)
+}
+
+func TestDiagram_captionIsTitle(t *testing.T) {
+	d := NewDiagram()
+	d.Place(shape.NewRect("a"))
+	d.SetCaption("Figure 1. A & B")
+	var buf bytes.Buffer
+	d.WriteSvg(&buf)
+	assert := asserter.New(t)
+	got := buf.String()
+	assert(strings.Contains(got, "<title>Figure 1. A &amp; B</title>")).Error(got)
+	assert(d.Title == "").Error("caption kept as title")
+}
+
+func TestDiagram_AdaptSize_arrowLabels(t *testing.T) {
+	d := NewDiagram()
+	a := shape.NewArrow(10, 10, 50, 10)
+	l := a.AddLabel(shape.AtMiddle, "a label wider than the arrow")
+	d.Place(a)
+	w, _ := d.AdaptSize()
+	x, _ := l.Position()
+	assert := asserter.New(t)
+	assert(w >= x+l.Width()).Errorf("width %v excludes label", w)
+}
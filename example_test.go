@@ -61,6 +61,24 @@ func ExampleClassDiagram() {
 	d.SaveAs("img/class_example.svg")
 }
 
+func ExampleClassDiagram_Layout() {
+	var (
+		d = design.NewClassDiagram()
+		_ = d.Interface((*shape.Shape)(nil))
+		_ = d.Interface((*shape.Edge)(nil))
+		_ = d.Struct(shape.Record{})
+		_ = d.Struct(shape.Arrow{})
+		_ = d.Struct(shape.Label{})
+		_ = d.Struct(shape.Font{})
+		_ = d.Struct(shape.Padding{})
+	)
+	d.HideRealizations()
+	d.Layout()
+	d.Orthogonal = true
+	d.SetCaption("Figure 2. Class diagram placed by layout")
+	d.SaveAs("img/class_layout.svg")
+}
+
 func ExampleSequenceDiagram() {
 	var (
 		d   = design.NewSequenceDiagram()
@@ -155,6 +173,7 @@ func ExampleActivityDiagram() {
 
 func TestExamples(t *testing.T) {
 	ExampleClassDiagram()
+	ExampleClassDiagram_Layout()
 	ExampleSequenceDiagram()
 	ExampleDiagram()
 	ExampleActivityDiagram()
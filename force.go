@@ -0,0 +1,132 @@
+package design
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/gregoryv/go-design/shape"
+	"github.com/gregoryv/go-design/xy"
+)
+
+// NewForceLayout returns a force directed layout with default
+// settings, shapes are placed the same for the same seed.
+func NewForceLayout(seed int64) *ForceLayout {
+	return &ForceLayout{
+		Seed:       seed,
+		Iterations: 300,
+		Length:     120,
+		Margin:     20,
+	}
+}
+
+// ForceLayout places shapes as if arrows were springs pulling
+// connected shapes together while all shapes push each other
+// away. It suits graphs without layers, e.g. a network of services.
+type ForceLayout struct {
+	// Seed of the random initial placement
+	Seed       int64
+	Iterations int
+	// Length is the ideal distance between connected shapes
+	Length int
+	// Margin to the top left corner of the diagram
+	Margin int
+}
+
+// LayoutForce places all shapes of the diagram, except arrows, lines
+// separated afterwards and arrows reconnected or routed again.
+// separated afterwards and arrows reconnected.
+func (d *Diagram) LayoutForce(f *ForceLayout) {
+	var (
+		boxes = d.boxes()
+		nodes = make([]*node, len(boxes))
+		index = make(map[shape.Shape]int)
+		rnd   = rand.New(rand.NewSource(f.Seed))
+		k     = float64(f.Length)
+		side  = k * math.Sqrt(float64(len(boxes)))
+	)
+	for i, b := range boxes {
+		nodes[i] = &node{
+			s:   b.s,
+			pos: xy.Point{X: rnd.Float64() * side, Y: rnd.Float64() * side},
+			w:   float64(b.s.Width()),
+			h:   float64(b.s.Height()),
+		}
+		index[b.s] = i
+	}
+	springs := make([][2]int, 0)
+	for _, arrow := range d.arrows() {
+		from, to := arrow.Between()
+		a, okA := index[from]
+		b, okB := index[to]
+		if okA && okB && a != b {
+			springs = append(springs, [2]int{a, b})
+		}
+	}
+	for i := 0; i < f.Iterations; i++ {
+		// cool down linearly
+		t := k * (1 - float64(i)/float64(f.Iterations))
+		for _, n := range nodes {
+			n.disp = xy.Vector{}
+		}
+		for i, a := range nodes {
+			for _, b := range nodes[i+1:] {
+				dir, dist := a.towards(b, rnd)
+				push := dir.Scale(k * k / dist)
+				a.disp = a.disp.Sub(push)
+				b.disp = b.disp.Add(push)
+			}
+		}
+		for _, s := range springs {
+			a, b := nodes[s[0]], nodes[s[1]]
+			dir, dist := a.towards(b, rnd)
+			pull := dir.Scale(dist * dist / k)
+			a.disp = a.disp.Add(pull)
+			b.disp = b.disp.Sub(pull)
+		}
+		for _, n := range nodes {
+			l := n.disp.Len()
+			if l == 0 {
+				continue
+			}
+			n.pos = n.pos.Add(n.disp.Normalize().Scale(math.Min(l, t)))
+		}
+	}
+	// move all so the top left most shape is at the margin
+	left, top := math.MaxFloat64, math.MaxFloat64
+	for _, n := range nodes {
+		left = math.Min(left, n.pos.X-n.w/2)
+		top = math.Min(top, n.pos.Y-n.h/2)
+	}
+	for _, n := range nodes {
+		x := int(math.Round(n.pos.X-n.w/2-left)) + f.Margin
+		y := int(math.Round(n.pos.Y-n.h/2-top)) + f.Margin
+		shape.MoveTo(n.s, x, y)
+	}
+	d.Separate()
+}
+
+// node is a shape being placed by the force directed layout, pos is
+// its center.
+type node struct {
+	s    shape.Shape
+	pos  xy.Point
+	w, h float64
+	disp xy.Vector
+}
+
+// towards returns the unit direction from n to o and the distance
+// between their boxes, at least 1. Nodes on top of each other get a
+// random direction.
+func (n *node) towards(o *node, rnd *rand.Rand) (xy.Vector, float64) {
+	v := o.pos.Sub(n.pos)
+	if v.Len() == 0 {
+		a := rnd.Float64() * 2 * math.Pi
+		v = xy.Vector{X: math.Cos(a), Y: math.Sin(a)}
+	}
+	var (
+		dx   = math.Abs(v.X) - (n.w+o.w)/2
+		dy   = math.Abs(v.Y) - (n.h+o.h)/2
+		dist = math.Max(math.Max(dx, dy), 1)
+	)
+	return v.Normalize(), dist
+}
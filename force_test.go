@@ -0,0 +1,58 @@
+package design
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gregoryv/asserter"
+	"github.com/gregoryv/go-design/shape"
+)
+
+func TestDiagram_LayoutForce(t *testing.T) {
+	positions := func(seed int64) ([]shape.Shape, *Diagram) {
+		d := NewDiagram()
+		names := []string{
+			"gateway", "auth", "users", "orders", "payments",
+			"inventory", "mail", "queue",
+		}
+		s := make([]shape.Shape, len(names))
+		for i, name := range names {
+			s[i] = shape.NewComponent(name)
+			d.Place(s[i])
+		}
+		links := [][2]int{
+			{0, 1}, {0, 2}, {0, 3}, {3, 4}, {3, 5}, {3, 7},
+			{7, 6}, {2, 1}, {4, 7},
+		}
+		for _, l := range links {
+			d.Link(s[l[0]], s[l[1]], "")
+		}
+		d.LayoutForce(NewForceLayout(seed))
+		return s, &d
+	}
+	a, d := positions(1)
+	b, _ := positions(1)
+	c, _ := positions(2)
+	d.AdaptSize()
+	d.SaveAs("img/force_layout.svg")
+
+	assert := asserter.New(t)
+	assert(d.Validate() == nil).Error(d.Validate())
+	var same, other bool = true, true
+	for i := range a {
+		pa, pb, pc := pos(a[i]), pos(b[i]), pos(c[i])
+		same = same && pa == pb
+		other = other && pa == pc
+	}
+	assert(same).Error("same seed gave different layouts")
+	assert(!other).Error("different seeds gave the same layout")
+	for _, s := range a {
+		x, y := s.Position()
+		assert(x >= 0 && y >= 0).Errorf("%v at %v,%v", s, x, y)
+	}
+}
+
+func pos(s shape.Shape) string {
+	x, y := s.Position()
+	return fmt.Sprint(x, ",", y)
+}
@@ -0,0 +1,359 @@
+package design
+
+import (
+	"sort"
+
+	"github.com/gregoryv/go-design/shape"
+)
+
+// NewLayerLayout returns a layered layout with default spacing.
+func NewLayerLayout() *LayerLayout {
+	return &LayerLayout{
+		Margin: 20,
+		HSpace: 40,
+		VSpace: 80,
+	}
+}
+
+// LayerLayout places records in rows, see ClassDiagram.LayoutLayers.
+type LayerLayout struct {
+	// Margin to the top left corner of the diagram
+	Margin int
+	// HSpace is the space between records in a row
+	HSpace int
+	// VSpace is the space between rows
+	VSpace int
+}
+
+// Layout places all records of the diagram in layers using the
+// default NewLayerLayout.
+func (d *ClassDiagram) Layout() { d.LayoutLayers(NewLayerLayout()) }
+
+// LayoutLayers places all records of the diagram in layers.
+// Interfaces and embedded types are placed above the records
+// implementing or embedding them and referred types beside their
+// owners. Records within a layer are ordered to minimise crossing
+// arrows. Records not yet placed on the diagram are added.
+func (d *ClassDiagram) LayoutLayers(l *LayerLayout) {
+	var (
+		up     = d.recordLinks(d.implements(), d.extends(), d.embeds(), d.binds())
+		beside = d.recordLinks(d.composes(), d.uses(), d.depends(), d.constrains())
+		all    = d.allRecords()
+		g      = newLayerGraph(all, up, beside)
+	)
+	for _, vr := range all {
+		if !d.placed(vr.Record) {
+			d.Place(vr)
+		}
+	}
+	g.rank()
+	g.order()
+	g.place(l.Margin, l.Margin, l.HSpace, l.VSpace)
+}
+
+// allRecords returns every record of the diagram, including generics
+// and constraints.
+func (d *ClassDiagram) allRecords() []VRecord {
+	all := d.records()
+	all = append(all, d.generics...)
+	return append(all, d.constraints...)
+}
+
+// placed returns true if the record is in the diagram content.
+func (d *ClassDiagram) placed(r *shape.Record) bool {
+	for _, s := range d.Content {
+		switch s := s.(type) {
+		case VRecord:
+			if s.Record == r {
+				return true
+			}
+		case *shape.Record:
+			if s == r {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// recordLink is a directed relation between two records.
+type recordLink struct {
+	from, to *shape.Record
+}
+
+// recordLinks returns the records each arrow is drawn between.
+func (d *ClassDiagram) recordLinks(rel ...[]shape.Shape) []recordLink {
+	links := make([]recordLink, 0)
+	for _, shapes := range rel {
+		for _, s := range shapes {
+			arrow, ok := s.(*shape.Arrow)
+			if !ok {
+				continue
+			}
+			from, to := arrow.Between()
+			a, b := recordOf(from), recordOf(to)
+			if a == nil || b == nil || a == b {
+				continue
+			}
+			links = append(links, recordLink{a, b})
+		}
+	}
+	return links
+}
+
+func recordOf(s shape.Shape) *shape.Record {
+	switch s := s.(type) {
+	case VRecord:
+		return s.Record
+	case *shape.Record:
+		return s
+	}
+	return nil
+}
+
+// layerGraph orders records into layers, layer 0 being the top.
+type layerGraph struct {
+	nodes  []*shape.Record
+	up     []recordLink // from is placed below to
+	beside []recordLink // from and to are placed in the same layer
+	layer  map[*shape.Record]int
+	layers [][]*shape.Record
+}
+
+func newLayerGraph(all []VRecord, up, beside []recordLink) *layerGraph {
+	nodes := make([]*shape.Record, len(all))
+	for i, vr := range all {
+		nodes[i] = vr.Record
+	}
+	return &layerGraph{
+		nodes:  nodes,
+		up:     up,
+		beside: beside,
+		layer:  make(map[*shape.Record]int),
+	}
+}
+
+// rank assigns each record to a layer.
+func (g *layerGraph) rank() {
+	// longest path up to a record without anything above it
+	visiting := make(map[*shape.Record]bool)
+	var rank func(n *shape.Record) int
+	rank = func(n *shape.Record) int {
+		if l, done := g.layer[n]; done {
+			return l
+		}
+		if visiting[n] {
+			return 0 // cycle
+		}
+		visiting[n] = true
+		l := 0
+		for _, e := range g.up {
+			if e.from == n {
+				if r := rank(e.to) + 1; r > l {
+					l = r
+				}
+			}
+		}
+		g.layer[n] = l
+		return l
+	}
+	for _, n := range g.nodes {
+		rank(n)
+	}
+	// records without relations up or down follow their owner
+	free := make(map[*shape.Record]bool)
+	for _, n := range g.nodes {
+		free[n] = true
+	}
+	for _, e := range g.up {
+		free[e.from], free[e.to] = false, false
+	}
+	for range g.nodes {
+		moved := false
+		for _, e := range g.beside {
+			if free[e.to] && g.layer[e.to] != g.layer[e.from] {
+				g.layer[e.to] = g.layer[e.from]
+				moved = true
+			}
+		}
+		if !moved {
+			break
+		}
+	}
+	var max int
+	for _, l := range g.layer {
+		if l > max {
+			max = l
+		}
+	}
+	layers := make([][]*shape.Record, max+1)
+	for _, n := range g.nodes {
+		l := g.layer[n]
+		layers[l] = append(layers[l], n)
+	}
+	g.layers = make([][]*shape.Record, 0, len(layers))
+	for _, layer := range layers {
+		if len(layer) == 0 {
+			continue
+		}
+		for _, n := range layer {
+			g.layer[n] = len(g.layers)
+		}
+		g.layers = append(g.layers, layer)
+	}
+}
+
+// order sorts records within each layer by the mean position of
+// their neighbours, keeping the order with fewest crossings.
+func (g *layerGraph) order() {
+	best := g.copyLayers()
+	least := g.crossings()
+	for sweep := 0; sweep < 8 && least > 0; sweep++ {
+		if sweep%2 == 0 {
+			for l := 1; l < len(g.layers); l++ {
+				g.sortLayer(l, l-1)
+			}
+		} else {
+			for l := len(g.layers) - 2; l >= 0; l-- {
+				g.sortLayer(l, l+1)
+			}
+		}
+		if c := g.crossings(); c < least {
+			least = c
+			best = g.copyLayers()
+		}
+	}
+	g.layers = best
+}
+
+func (g *layerGraph) copyLayers() [][]*shape.Record {
+	c := make([][]*shape.Record, len(g.layers))
+	for i, l := range g.layers {
+		c[i] = append([]*shape.Record{}, l...)
+	}
+	return c
+}
+
+// sortLayer orders layer l by the barycenter of neighbours in layer
+// ref. Records without such neighbours follow neighbours in the same
+// layer or keep their position.
+func (g *layerGraph) sortLayer(l, ref int) {
+	pos := g.positions()
+	center := make(map[*shape.Record]float64)
+	for i, n := range g.layers[l] {
+		sum, count := 0.0, 0
+		same := 0.0
+		sameCount := 0
+		for _, m := range g.neighbours(n) {
+			switch g.layer[m] {
+			case ref:
+				sum += float64(pos[m])
+				count++
+			case l:
+				same += float64(pos[m]) + 0.5
+				sameCount++
+			}
+		}
+		switch {
+		case count > 0:
+			center[n] = sum / float64(count)
+		case sameCount > 0:
+			center[n] = same / float64(sameCount)
+		default:
+			center[n] = float64(i)
+		}
+	}
+	sort.SliceStable(g.layers[l], func(i, j int) bool {
+		return center[g.layers[l][i]] < center[g.layers[l][j]]
+	})
+}
+
+func (g *layerGraph) neighbours(n *shape.Record) []*shape.Record {
+	res := make([]*shape.Record, 0)
+	for _, links := range [][]recordLink{g.up, g.beside} {
+		for _, e := range links {
+			switch n {
+			case e.from:
+				res = append(res, e.to)
+			case e.to:
+				res = append(res, e.from)
+			}
+		}
+	}
+	return res
+}
+
+// positions returns the index of each record within its layer.
+func (g *layerGraph) positions() map[*shape.Record]int {
+	pos := make(map[*shape.Record]int)
+	for _, layer := range g.layers {
+		for i, n := range layer {
+			pos[n] = i
+		}
+	}
+	return pos
+}
+
+// crossings returns the number of crossing links between adjacent
+// layers.
+func (g *layerGraph) crossings() int {
+	pos := g.positions()
+	all := make([]recordLink, 0, len(g.up)+len(g.beside))
+	all = append(all, g.up...)
+	all = append(all, g.beside...)
+	links := make([]recordLink, 0)
+	for _, e := range all {
+		a, b := g.layer[e.from], g.layer[e.to]
+		switch {
+		case a == b+1:
+			links = append(links, recordLink{e.to, e.from})
+		case b == a+1:
+			links = append(links, e)
+		}
+	}
+	var count int
+	for i, e := range links {
+		for _, f := range links[i+1:] {
+			if g.layer[e.from] != g.layer[f.from] {
+				continue
+			}
+			d1 := pos[e.from] - pos[f.from]
+			d2 := pos[e.to] - pos[f.to]
+			if d1*d2 < 0 {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// place positions the layers as rows starting at x, y. Each row is
+// centered to the widest row.
+func (g *layerGraph) place(x, y, hspace, vspace int) {
+	widths := make([]int, len(g.layers))
+	var widest int
+	for i, layer := range g.layers {
+		for j, n := range layer {
+			if j > 0 {
+				widths[i] += hspace
+			}
+			widths[i] += n.Width()
+		}
+		if widths[i] > widest {
+			widest = widths[i]
+		}
+	}
+	for i, layer := range g.layers {
+		left := x + (widest-widths[i])/2
+		var height int
+		for _, n := range layer {
+			n.SetX(left)
+			n.SetY(y)
+			left += n.Width() + hspace
+			if n.Height() > height {
+				height = n.Height()
+			}
+		}
+		y += height + vspace
+	}
+}
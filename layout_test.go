@@ -0,0 +1,66 @@
+package design
+
+import (
+	"io"
+	"testing"
+
+	"github.com/gregoryv/asserter"
+	"github.com/gregoryv/go-design/shape"
+)
+
+func TestClassDiagram_Layout(t *testing.T) {
+	d := NewClassDiagram()
+	var (
+		rwc = d.Interface((*io.ReadWriteCloser)(nil))
+		r   = d.Interface((*io.Reader)(nil))
+		w   = d.Interface((*io.Writer)(nil))
+		s   = d.Struct(rw{})
+		c   = d.Struct(car{})
+		e   = d.Struct(engine{})
+	)
+	d.Layout()
+	assert := asserter.New(t)
+	assert(above(r, rwc)).Error("extended interface not above")
+	assert(above(w, s)).Error("interface not above implementation")
+	assert(sameRow(c, e)).Error("composed type not beside owner")
+	for _, vr := range []VRecord{rwc, r, w, s, c, e} {
+		assert(d.placed(vr.Record)).Errorf("%s not placed", vr.Title)
+	}
+	d.Place(r) // already placed records are not added again
+	before := len(d.Content)
+	d.Layout()
+	assert(len(d.Content) == before).Error("records placed twice")
+
+	l := NewLayerLayout()
+	l.Margin = 100
+	d.LayoutLayers(l)
+	x, y := r.Position()
+	assert(x >= 100 && y == 100).Errorf("margin not used: %v,%v", x, y)
+}
+
+func TestLayerGraph_order(t *testing.T) {
+	var (
+		a, b   = shape.NewRecord("a"), shape.NewRecord("b")
+		c, dd  = shape.NewRecord("c"), shape.NewRecord("d")
+		recs   = []VRecord{{Record: a}, {Record: b}, {Record: c}, {Record: dd}}
+		up     = []recordLink{{c, b}, {dd, a}}
+		g      = newLayerGraph(recs, up, nil)
+		assert = asserter.New(t)
+	)
+	g.rank()
+	assert(g.crossings() == 1).Errorf("crossings before: %v", g.crossings())
+	g.order()
+	assert(g.crossings() == 0).Errorf("crossings after: %v", g.crossings())
+}
+
+func above(a, b VRecord) bool {
+	_, ay := a.Position()
+	_, by := b.Position()
+	return ay+a.Height() < by
+}
+
+func sameRow(a, b VRecord) bool {
+	_, ay := a.Position()
+	_, by := b.Position()
+	return ay == by
+}
@@ -1,8 +1,10 @@
 package design
 
 import (
+	"fmt"
 	"io"
 	"reflect"
+	"strings"
 
 	"github.com/gregoryv/go-design/shape"
 )
@@ -37,7 +39,13 @@ func (d *SequenceDiagram) WriteSvg(w io.Writer) error {
 		y1  = top + d.TextPad.Bottom + d.Font.LineHeight // below label
 		y2  = d.Height()
 	)
+	if d.Desc == "" {
+		// read by screen readers in order
+		d.Desc = d.messages()
+		defer func() { d.Desc = "" }()
+	}
 	lines := make([]*shape.Line, len(d.columns))
+	labels := make([]shape.Shape, len(d.columns))
 	for i, column := range d.columns {
 		label := shape.NewLabel(column)
 		label.Font = d.Font
@@ -55,7 +63,12 @@ func (d *SequenceDiagram) WriteSvg(w io.Writer) error {
 		x += colWidth
 
 		d.VAlignCenter(lines[i], label)
-		d.Place(lines[i], label)
+		labels[i] = label
+	}
+	// column names are read before the messages
+	d.Place(labels...)
+	for _, line := range lines {
+		d.Place(line)
 	}
 
 	y := y1 + d.plainHeight()
@@ -86,7 +99,7 @@ func (d *SequenceDiagram) WriteSvg(w io.Writer) error {
 				l2.End.Y,
 			)
 			arrow.SetClass(lnk.class())
-			d.Place(l1, l2, arrow, label)
+			d.Place(label, l1, l2, arrow)
 			y += d.selfHeight()
 		} else {
 			arrow := shape.NewArrow(
@@ -97,13 +110,28 @@ func (d *SequenceDiagram) WriteSvg(w io.Writer) error {
 			)
 			arrow.SetClass(lnk.class())
 			d.VAlignCenter(arrow, label)
-			d.Place(arrow, label)
+			d.Place(label, arrow)
 			y += d.plainHeight()
 		}
 	}
 	return d.Diagram.WriteSvg(w)
 }
 
+// messages returns all links in order, one per line written as
+// "1. a to b: text".
+func (d *SequenceDiagram) messages() string {
+	var buf strings.Builder
+	for i, lnk := range d.links {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		fmt.Fprintf(&buf, "%v. %s to %s: %s",
+			i+1, d.columns[lnk.fromIndex], d.columns[lnk.toIndex], lnk.text,
+		)
+	}
+	return buf.String()
+}
+
 // Width returns the total width of the diagram
 func (d *SequenceDiagram) Width() int {
 	if d.Svg.Width != 0 {
@@ -3,6 +3,7 @@ package design
 import (
 	"bytes"
 	"io/ioutil"
+	"strings"
 	"testing"
 
 	"github.com/gregoryv/asserter"
@@ -75,3 +76,24 @@ func (t *one_sequence_diagram) is_rendered_with_fixed_size() {
 	t.WriteSvg(w)
 	golden.Assert(t, w.String())
 }
+
+func TestSequenceDiagram_readingOrder(t *testing.T) {
+	d := NewSequenceDiagram()
+	d.AddColumns("a", "b")
+	d.Link("a", "b", "hello")
+	d.Link("b", "b", "think")
+	var buf bytes.Buffer
+	d.WriteSvg(&buf)
+	got := buf.String()
+	assert := asserter.New(t)
+	exp := "<desc>1. a to b: hello\n2. b to b: think</desc>"
+	assert(strings.Contains(got, exp)).Errorf("missing %s in\n%s", exp, got)
+	order := []string{">a</text>", ">b</text>", "<line", ">hello</text>", "<path", ">think</text>"}
+	last := -1
+	for _, txt := range order {
+		i := strings.Index(got, txt)
+		assert(i > last).Errorf("%s out of order in\n%s", txt, got)
+		last = i
+	}
+	assert(d.Desc == "").Error("generated description kept")
+}
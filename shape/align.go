@@ -1,5 +1,7 @@
 package shape
 
+import "math"
+
 // Aligner type aligns multiple shapes
 type Aligner struct{}
 
@@ -89,3 +91,119 @@ const (
 	LR
 	RL
 )
+
+// HDistribute spaces shapes evenly between the first and last so the
+// gaps between them are equal.
+func (Aligner) HDistribute(shapes ...Shape) { distribute(false, false, shapes) }
+
+// HDistributeCenters spaces shapes so the distance between their
+// centers is equal, the first and last are not moved.
+func (Aligner) HDistributeCenters(shapes ...Shape) { distribute(false, true, shapes) }
+
+// VDistribute spaces shapes evenly between the first and last so the
+// gaps between them are equal.
+func (Aligner) VDistribute(shapes ...Shape) { distribute(true, false, shapes) }
+
+// VDistributeCenters spaces shapes so the distance between their
+// centers is equal, the first and last are not moved.
+func (Aligner) VDistributeCenters(shapes ...Shape) { distribute(true, true, shapes) }
+
+func distribute(vertical, centers bool, shapes []Shape) {
+	n := len(shapes)
+	if n < 3 {
+		return
+	}
+	size := func(s Shape) int {
+		if vertical {
+			return s.Height()
+		}
+		return s.Width()
+	}
+	var (
+		first = shapes[0]
+		last  = shapes[n-1]
+		start = coord(first, vertical)
+		end   = coord(last, vertical)
+	)
+	if centers {
+		from := start + size(first)/2
+		step := float64(end+size(last)/2-from) / float64(n-1)
+		for i, s := range shapes[1 : n-1] {
+			c := from + int(math.Round(step*float64(i+1)))
+			place(s, vertical, c-size(s)/2)
+		}
+		return
+	}
+	free := end - start - size(first)
+	for _, s := range shapes[1 : n-1] {
+		free -= size(s)
+	}
+	gap := float64(free) / float64(n-1)
+	pos := float64(start + size(first))
+	for _, s := range shapes[1 : n-1] {
+		pos += gap
+		place(s, vertical, int(math.Round(pos)))
+		pos += float64(size(s))
+	}
+}
+
+// MatchWidth makes all resizable shapes as wide as the widest shape.
+func (Aligner) MatchWidth(shapes ...Shape) {
+	var w int
+	for _, s := range shapes {
+		w = maxInt(w, s.Width())
+	}
+	for _, s := range shapes {
+		if s, ok := s.(Resizable); ok {
+			s.SetWidth(w)
+		}
+	}
+}
+
+// MatchHeight makes all resizable shapes as high as the highest
+// shape.
+func (Aligner) MatchHeight(shapes ...Shape) {
+	var h int
+	for _, s := range shapes {
+		h = maxInt(h, s.Height())
+	}
+	for _, s := range shapes {
+		if s, ok := s.(Resizable); ok {
+			s.SetHeight(h)
+		}
+	}
+}
+
+// HStack places shapes[1:] right of each other starting at the first
+// with gap pixels between them and their tops aligned.
+func (Aligner) HStack(gap int, shapes ...Shape) { stack(false, gap, shapes) }
+
+// VStack places shapes[1:] below each other starting at the first
+// with gap pixels between them and their left sides aligned.
+func (Aligner) VStack(gap int, shapes ...Shape) { stack(true, gap, shapes) }
+
+func stack(vertical bool, gap int, shapes []Shape) {
+	if len(shapes) < 2 {
+		return
+	}
+	x, y := shapes[0].Position()
+	for i, s := range shapes[1:] {
+		prev := shapes[i]
+		if vertical {
+			_, py := prev.Position()
+			MoveTo(s, x, py+prev.Height()+gap)
+		} else {
+			px, _ := prev.Position()
+			MoveTo(s, px+prev.Width()+gap, y)
+		}
+	}
+}
+
+// place moves s to v along one axis.
+func place(s Shape, vertical bool, v int) {
+	if vertical {
+		MoveTo(s, coord(s, false), v)
+		return
+	}
+	MoveTo(s, v, coord(s, true))
+}
@@ -17,12 +17,12 @@ func TestAlignVertical(t *testing.T) {
 		{
 			aligner.VAlignCenter,
 			&Label{
-				Pos:  xy.Position{10, 10},
+				Pos:  xy.Position{X: 10, Y: 10},
 				Text: "1234",
 				Font: DefaultFont,
 			},
 			&Label{
-				Pos:  xy.Position{50, 40},
+				Pos:  xy.Position{X: 50, Y: 40},
 				Text: "12",
 				Font: DefaultFont,
 			},
@@ -31,12 +31,12 @@ func TestAlignVertical(t *testing.T) {
 		{
 			aligner.VAlignLeft,
 			&Label{
-				Pos:  xy.Position{10, 10},
+				Pos:  xy.Position{X: 10, Y: 10},
 				Text: "1234",
 				Font: DefaultFont,
 			},
 			&Label{
-				Pos:  xy.Position{50, 40},
+				Pos:  xy.Position{X: 50, Y: 40},
 				Text: "12",
 				Font: DefaultFont,
 			},
@@ -45,12 +45,12 @@ func TestAlignVertical(t *testing.T) {
 		{
 			aligner.VAlignRight,
 			&Label{
-				Pos:  xy.Position{10, 10},
+				Pos:  xy.Position{X: 10, Y: 10},
 				Text: "1234",
 				Font: DefaultFont,
 			},
 			&Label{
-				Pos:  xy.Position{50, 40},
+				Pos:  xy.Position{X: 50, Y: 40},
 				Text: "12",
 				Font: DefaultFont,
 			},
@@ -81,14 +81,14 @@ func TestAlignHorizontal(t *testing.T) {
 	}{
 		{
 			aligner.HAlignTop,
-			&Label{Pos: xy.Position{10, 10}},
-			&Label{Pos: xy.Position{50, 40}},
+			&Label{Pos: xy.Position{X: 10, Y: 10}},
+			&Label{Pos: xy.Position{X: 50, Y: 40}},
 			50, 10,
 		},
 		{
 			aligner.HAlignBottom,
-			&Label{Pos: xy.Position{10, 10}},
-			&Label{Pos: xy.Position{50, 40}},
+			&Label{Pos: xy.Position{X: 10, Y: 10}},
+			&Label{Pos: xy.Position{X: 50, Y: 40}},
 			50, 10,
 		},
 		{
@@ -119,7 +119,7 @@ func TestAlignHorizontal(t *testing.T) {
 			aligner.HAlignCenter,
 			NewLine(0, 10, 0, 20),
 			&Label{
-				Pos:  xy.Position{0, 20},
+				Pos:  xy.Position{X: 0, Y: 20},
 				Font: Font{LineHeight: 10},
 			},
 			0, 20,
@@ -127,11 +127,11 @@ func TestAlignHorizontal(t *testing.T) {
 		{
 			aligner.HAlignCenter,
 			&Label{
-				Pos:  xy.Position{0, 20},
+				Pos:  xy.Position{X: 0, Y: 20},
 				Font: Font{LineHeight: 10},
 			},
 			&Label{
-				Pos:  xy.Position{0, 20},
+				Pos:  xy.Position{X: 0, Y: 20},
 				Font: Font{LineHeight: 6},
 			},
 			0, 28,
@@ -145,3 +145,75 @@ func TestAlignHorizontal(t *testing.T) {
 		assert(y == c.expY).Errorf("%v. Y was %v, expected %v", i, y, c.expY)
 	}
 }
+
+func TestAligner_HDistribute(t *testing.T) {
+	var (
+		aligner Aligner
+		a       = NewRect("a")
+		b       = NewRect("b is wide")
+		c       = NewRect("c")
+		d       = NewRect("d")
+	)
+	a.SetX(0)
+	d.SetX(300)
+	aligner.HDistribute(a, b, c, d)
+	gaps := []int{
+		b.X - (a.X + a.Width()),
+		c.X - (b.X + b.Width()),
+		d.X - (c.X + c.Width()),
+	}
+	assert := asserter.New(t)
+	for _, g := range gaps[1:] {
+		assert(intAbs(g-gaps[0]) <= 1).Errorf("uneven gaps %v", gaps)
+	}
+	assert(d.X == 300).Errorf("last moved to %v", d.X)
+}
+
+func TestAligner_VDistributeCenters(t *testing.T) {
+	var (
+		aligner Aligner
+		a       = NewRect("a")
+		b       = NewRecord("b")
+		c       = NewRect("c")
+	)
+	b.Fields = []string{"x", "y"}
+	c.SetY(200)
+	aligner.VDistributeCenters(a, b, c)
+	assert := asserter.New(t)
+	ca, cb, cc := center(a).Y, center(b).Y, center(c).Y
+	assert(intAbs((cb-ca)-(cc-cb)) <= 1).Errorf("centers %v %v %v", ca, cb, cc)
+}
+
+func TestAligner_MatchWidth(t *testing.T) {
+	var (
+		aligner Aligner
+		a       = NewRect("a")
+		b       = NewRecord("b is wider")
+		c       = NewCircle(60)
+	)
+	aligner.MatchWidth(a, b, c)
+	assert := asserter.New(t)
+	assert(a.Width() == c.Width()).Errorf("rect %v", a.Width())
+	assert(b.Width() == c.Width()).Errorf("record %v", b.Width())
+	aligner.MatchHeight(a, b)
+	assert(a.Height() == b.Height()).Errorf("%v != %v", a.Height(), b.Height())
+}
+
+func TestAligner_Stack(t *testing.T) {
+	var (
+		aligner Aligner
+		a       = NewRect("a")
+		b       = NewRect("b")
+		c       = NewDecision()
+	)
+	a.SetX(10)
+	a.SetY(20)
+	aligner.VStack(5, a, b, c)
+	assert := asserter.New(t)
+	assert(b.X == 10 && b.Y == 20+a.Height()+5).Errorf("b at %v,%v", b.X, b.Y)
+	cx, cy := c.Position()
+	assert(cx == 10 && cy == b.Y+b.Height()+5).Errorf("c at %v,%v", cx, cy)
+	aligner.HStack(5, a, b, c)
+	cx, cy = c.Position()
+	assert(cy == 20 && cx == b.X+b.Width()+5).Errorf("c at %v,%v", cx, cy)
+}
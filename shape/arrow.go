@@ -10,19 +10,34 @@ import (
 
 func NewArrow(x1, y1, x2, y2 int) *Arrow {
 	return &Arrow{
-		Start: xy.Position{x1, y1},
-		End:   xy.Position{x2, y2},
+		Start: xy.Position{X: x1, Y: y1},
+		End:   xy.Position{X: x2, Y: y2},
 		Head:  NewTriangle(x2, y2, "arrow-head"),
 		class: "arrow",
 	}
 }
 
 type Arrow struct {
+	Hyperlink
+
 	Start xy.Position
 	End   xy.Position
-	Tail  Shape
-	Head  Shape
-	class string
+	// Waypoints are optional positions the arrow passes between
+	// start and end.
+	Waypoints []xy.Position
+	// Curved renders a smooth curve passing through all points
+	// instead of straight segments.
+	Curved bool
+	Tail   Shape
+	Head   Shape
+	// Labels follow the arrow and are written with it.
+	Labels []*ArrowLabel
+	class  string
+
+	// set when created between shapes
+	from, to Shape
+	// routed orthogonally, even without waypoints
+	routed bool
 }
 
 func (a *Arrow) String() string {
@@ -33,26 +48,55 @@ func (arrow *Arrow) WriteSvg(out io.Writer) error {
 	w, err := newTagPrinter(out)
 	x1, y1 := arrow.Start.XY()
 	x2, y2 := arrow.End.XY()
-	w.printf(`<path class="%s" d="M%v,%v L%v,%v" />`, arrow.class, x1, y1, x2, y2)
-	w.print("\n")
+	d := fmt.Sprintf("M%v,%v", x1, y1)
+	if arrow.Curved {
+		for _, c := range arrow.curves() {
+			d += fmt.Sprintf(" C%v,%v %v,%v %v,%v",
+				c[0].X, c[0].Y, c[1].X, c[1].Y, c[2].X, c[2].Y,
+			)
+		}
+	} else {
+		for _, p := range arrow.Waypoints {
+			d += fmt.Sprintf(" L%v,%v", p.X, p.Y)
+		}
+		d += fmt.Sprintf(" L%v,%v", x2, y2)
+	}
+	w.empty("path", attr{"class", arrow.class}, attr{"d", d})
 	if arrow.Tail != nil {
-		w.printf(`<g transform="rotate(%v %v %v)">`, arrow.angle(), x1, y1)
+		w.open("g", attr{"transform", rotate(arrow.tailAngle(), x1, y1)})
 		alignTail(arrow.Tail, x1, y1)
 		arrow.Tail.SetClass(arrow.class + "-tail")
-		arrow.Tail.WriteSvg(out)
-		w.print("</g>\n")
+		arrow.Tail.WriteSvg(w)
+		w.close("g")
 	}
 	if arrow.Head != nil {
-		w.printf(`<g transform="rotate(%v %v %v)">`, arrow.angle(), x2, y2)
+		w.open("g", attr{"transform", rotate(arrow.angle(), x2, y2)})
 		arrow.Head.SetX(arrow.End.X)
 		arrow.Head.SetY(arrow.End.Y)
 		arrow.Head.SetClass(arrow.class + "-head")
-		arrow.Head.WriteSvg(out)
-		w.print("</g>\n")
+		arrow.Head.WriteSvg(w)
+		w.close("g")
+	}
+	arrow.PlaceLabels()
+	for _, l := range arrow.Labels {
+		writeLinked(w, l)
 	}
 	return *err
 }
 
+// rotate returns an svg transform rotating degrees around x, y.
+func rotate(degrees float64, x, y int) string {
+	return fmt.Sprintf("rotate(%v %v %v)", degrees, x, y)
+}
+
+// PlaceLabels positions the labels next to the arrow. Labels are
+// placed when the arrow is written so they follow changes to it.
+func (arrow *Arrow) PlaceLabels() {
+	for _, l := range arrow.Labels {
+		l.place(arrow)
+	}
+}
+
 func alignTail(s Shape, x, y int) {
 	switch s := s.(type) {
 	case *Circle:
@@ -65,51 +109,59 @@ func alignTail(s Shape, x, y int) {
 }
 
 func (arrow *Arrow) absAngle() float64 {
-	return math.Abs(float64(arrow.angle()))
+	return math.Abs(arrow.angle())
+}
+
+// Points returns start, waypoints and end of the arrow.
+func (arrow *Arrow) Points() []xy.Position {
+	points := make([]xy.Position, 0, len(arrow.Waypoints)+2)
+	points = append(points, arrow.Start)
+	points = append(points, arrow.Waypoints...)
+	return append(points, arrow.End)
+}
+
+// curves returns the two control points and end of each cubic
+// Bezier curve passing through all points. The curves are
+// Catmull-Rom splines so the tangent at the start and end equals the
+// direction of the first and last segment.
+func (arrow *Arrow) curves() [][3]xy.Position {
+	points := arrow.Points()
+	n := len(points)
+	at := func(i int) xy.Point {
+		return points[maxInt(0, minInt(i, n-1))].Point()
+	}
+	curves := make([][3]xy.Position, 0, n-1)
+	for i := 0; i < n-1; i++ {
+		p0, p1, p2, p3 := at(i-1), at(i), at(i+1), at(i+2)
+		curves = append(curves, [3]xy.Position{
+			p1.Add(p2.Sub(p0).Scale(1.0 / 6)).Position(),
+			p2.Add(p3.Sub(p1).Scale(-1.0 / 6)).Position(),
+			p2.Position(),
+		})
+	}
+	return curves
 }
 
 // angle returns degrees the head of an arrow should rotate depending
-// on direction
-func (arrow *Arrow) angle() int {
-	var (
-		start = arrow.Start
-		end   = arrow.End
-		// straight arrows
-		right = start.LeftOf(end) && start.Y == end.Y
-		left  = start.RightOf(end) && start.Y == end.Y
-		down  = start.Above(end) && start.X == end.X
-		up    = start.Below(end) && start.X == end.X
-	)
-	switch {
-	case right: // most frequent arrow on top
-	case left:
-		return 180
-	case down:
-		return 90
-	case up:
-		return -90
-	case arrow.DirQ1():
-		a := float64(end.Y - start.Y)
-		b := float64(end.X - start.X)
-		A := math.Atan(a / b)
-		return radians2degrees(A)
-	case arrow.DirQ2():
-		a := float64(end.Y - start.Y)
-		b := float64(start.X - end.X)
-		A := math.Atan(a / b)
-		return 180 - radians2degrees(A)
-	case arrow.DirQ3():
-		a := float64(start.Y - end.Y)
-		b := float64(start.X - end.X)
-		A := math.Atan(a / b)
-		return radians2degrees(A) + 180
-	case arrow.DirQ4():
-		a := float64(start.Y - end.Y)
-		b := float64(end.X - start.X)
-		A := math.Atan(a / b)
-		return -radians2degrees(A)
-	}
-	return 0
+// on direction of the last segment.
+func (arrow *Arrow) angle() float64 {
+	points := arrow.Points()
+	n := len(points)
+	return angle(points[n-2], points[n-1])
+}
+
+// tailAngle returns degrees the tail of an arrow should rotate
+// depending on direction of the first segment.
+func (arrow *Arrow) tailAngle() float64 {
+	points := arrow.Points()
+	return angle(points[0], points[1])
+}
+
+// angle returns degrees of the direction from start to end,
+// rounded to two decimals.
+func angle(start, end xy.Position) float64 {
+	a := end.Point().Sub(start.Point()).Angle()
+	return math.Round(a*100) / 100
 }
 
 // DirQ1 returns true if the arrow points to the bottom-right
@@ -144,16 +196,33 @@ func (arrow *Arrow) endpoints() (xy.Position, xy.Position) {
 	return arrow.Start, arrow.End
 }
 
-func radians2degrees(A float64) int {
-	return int(A * 180 / math.Pi)
-}
-
 func (arrow *Arrow) Height() int {
-	return intAbs(arrow.Start.Y - arrow.End.Y)
+	_, top, _, bottom := arrow.bounds()
+	return bottom - top
 }
 
 func (arrow *Arrow) Width() int {
-	return intAbs(arrow.Start.X - arrow.End.X)
+	left, _, right, _ := arrow.bounds()
+	return right - left
+}
+
+// bounds returns the min and max coordinates of all points, labels
+// are not included.
+func (arrow *Arrow) bounds() (left, top, right, bottom int) {
+	left, top = arrow.Start.XY()
+	right, bottom = left, top
+	points := arrow.Points()[1:]
+	if arrow.Curved {
+		// curves stay within their control points
+		for _, c := range arrow.curves() {
+			points = append(points, c[0], c[1])
+		}
+	}
+	for _, p := range points {
+		left, right = minInt(left, p.X), maxInt(right, p.X)
+		top, bottom = minInt(top, p.Y), maxInt(bottom, p.Y)
+	}
+	return
 }
 
 func (arrow *Arrow) Position() (int, int) {
@@ -164,12 +233,18 @@ func (arrow *Arrow) SetX(x int) {
 	diff := arrow.Start.X - x
 	arrow.Start.X = x
 	arrow.End.X = arrow.End.X - diff // Set X2 so the entire arrow moves
+	for i := range arrow.Waypoints {
+		arrow.Waypoints[i].X -= diff
+	}
 }
 
 func (arrow *Arrow) SetY(y int) {
 	diff := arrow.Start.Y - y
 	arrow.Start.Y = y
 	arrow.End.Y = arrow.End.Y - diff // Set Y2 so the entire arrow moves
+	for i := range arrow.Waypoints {
+		arrow.Waypoints[i].Y -= diff
+	}
 }
 
 func (arrow *Arrow) Direction() Direction {
@@ -191,6 +266,7 @@ func NewArrowBetween(a, b Shape) *Arrow {
 	x2 := bx + b.Width()/2
 	y2 := by + b.Height()/2
 	arrow := NewArrow(x1, y1, x2, y2)
+	arrow.from, arrow.to = a, b
 	bs, ok := b.(Edge)
 	if ok {
 		p := bs.Edge(arrow.Start)
@@ -206,3 +282,70 @@ func NewArrowBetween(a, b Shape) *Arrow {
 
 	return arrow
 }
+
+// NewArrowVia returns an arrow from shape a to shape b passing the
+// given waypoints. The arrow starts at the edge of a facing the first
+// waypoint and ends at the edge of b facing the last.
+func NewArrowVia(a, b Shape, waypoints ...xy.Position) *Arrow {
+	arrow := NewArrowBetween(a, b)
+	if len(waypoints) == 0 {
+		return arrow
+	}
+	arrow.Waypoints = waypoints
+	arrow.Start = center(a)
+	arrow.End = center(b)
+	if bs, ok := b.(Edge); ok {
+		arrow.End = bs.Edge(waypoints[len(waypoints)-1])
+	}
+	if as, ok := a.(Edge); ok {
+		arrow.Start = as.Edge(waypoints[0])
+	}
+	return arrow
+}
+
+// NewCurvedArrow returns a curved arrow between a and b bending
+// sideways by bend pixels at the middle, use opposite signs to
+// separate parallel arrows.
+func NewCurvedArrow(a, b Shape, bend int) *Arrow {
+	var (
+		p1 = center(a).Point()
+		p2 = center(b).Point()
+		v  = p2.Sub(p1)
+	)
+	if v.Len() == 0 || bend == 0 {
+		arrow := NewArrowBetween(a, b)
+		arrow.Curved = true
+		return arrow
+	}
+	// perpendicular to the line between the centers
+	side := v.Normalize().Rotate(math.Pi / 2).Scale(float64(bend))
+	mid := p1.Add(v.Scale(0.5)).Add(side).Position()
+	arrow := NewArrowVia(a, b, mid)
+	arrow.Curved = true
+	return arrow
+}
+
+func center(s Box) xy.Position {
+	x, y := s.Position()
+	return xy.Position{X: x + s.Width()/2, Y: y + s.Height()/2}
+}
+
+// Reconnect moves the ends of an arrow created between shapes to the
+// current edges of the shapes, e.g. after they moved. Arrows not
+// created between shapes are left as is.
+func (arrow *Arrow) Reconnect() {
+	if arrow.from == nil || arrow.to == nil {
+		return
+	}
+	a := NewArrowVia(arrow.from, arrow.to, arrow.Waypoints...)
+	arrow.Start, arrow.End = a.Start, a.End
+}
+
+// Routed returns true if the arrow was routed by a Router.
+func (arrow *Arrow) Routed() bool { return arrow.routed }
+
+// Between returns the shapes the arrow was created between, both
+// are nil if the arrow was created from coordinates.
+func (arrow *Arrow) Between() (from, to Shape) {
+	return arrow.from, arrow.to
+}
@@ -3,10 +3,12 @@ package shape
 import (
 	"bytes"
 	"fmt"
+	"math"
 	"os"
 	"testing"
 
 	"github.com/gregoryv/asserter"
+	"github.com/gregoryv/go-design/xy"
 )
 
 func TestArrow(t *testing.T) {
@@ -208,3 +210,53 @@ func writeSvgTo(t *testing.T, filename string, svg *Svg) {
 	svg.WriteSvg(&style)
 	fh.Close()
 }
+
+func TestArrow_Between(t *testing.T) {
+	a, b := NewRect("a"), NewRect("b")
+	from, to := NewArrowBetween(a, b).Between()
+	assert := asserter.New(t)
+	assert(from == a && to == b).Error("wrong shapes")
+	from, to = NewArrow(0, 0, 1, 1).Between()
+	assert(from == nil && to == nil).Error("expected no shapes")
+}
+
+func TestArrow_Curved(t *testing.T) {
+	var (
+		a   = NewRect("a")
+		b   = NewRect("b")
+		svg = newSvg(260, 160, a, b)
+	)
+	a.SetX(10)
+	a.SetY(60)
+	b.SetX(200)
+	b.SetY(60)
+	there := NewCurvedArrow(a, b, 30)
+	back := NewCurvedArrow(b, a, 30)
+	svg.Append(there, back)
+	writeSvgTo(t, "testdata/arrow_curved.svg", svg)
+
+	assert := asserter.New(t)
+	buf := &bytes.Buffer{}
+	there.WriteSvg(buf)
+	assert().Contains(buf.String(), " C")
+	// bend is perpendicular to the direction of each arrow
+	assert(there.Waypoints[0].Y > 60).Errorf("%v not below", there.Waypoints)
+	assert(back.Waypoints[0].Y < 60).Errorf("%v not above", back.Waypoints)
+	// head follows the tangent at the end
+	last := there.curves()[1][1]
+	diff := math.Abs(there.angle() - angle(last, there.End))
+	assert(diff <= 3).Errorf("head angle %v", there.angle())
+	assert(there.Height() > 0).Error("curve not within bounds")
+}
+
+func TestNewArrowVia(t *testing.T) {
+	a := NewRect("a")
+	b := NewRect("b")
+	b.SetX(100)
+	b.SetY(100)
+	above := xy.Position{X: center(b).X, Y: 0}
+	arrow := NewArrowVia(a, b, above)
+	assert := asserter.New(t)
+	assert(arrow.End.Y == 100).Errorf("end %v not on top edge", arrow.End)
+	assert(arrow.angle() == 90).Errorf("head angle %v", arrow.angle())
+}
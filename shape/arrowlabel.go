@@ -0,0 +1,110 @@
+package shape
+
+import (
+	"math"
+
+	"github.com/gregoryv/go-design/xy"
+)
+
+// Anchor is the position along an arrow a label follows.
+type Anchor int
+
+const (
+	AtStart Anchor = iota
+	AtMiddle
+	AtEnd
+)
+
+// ArrowLabel is a label following the geometry of an arrow.
+type ArrowLabel struct {
+	*Label
+	At Anchor
+	// Below places the label on the other side of the arrow. Labels
+	// are above horizontal arrows and right of vertical ones by
+	// default.
+	Below bool
+}
+
+// AddLabel adds a label with the text at the given anchor and
+// returns it.
+func (arrow *Arrow) AddLabel(at Anchor, txt string) *ArrowLabel {
+	label := &ArrowLabel{
+		Label: NewLabel(txt),
+		At:    at,
+	}
+	arrow.Labels = append(arrow.Labels, label)
+	return label
+}
+
+// labelSpace is the distance between a label and the arrow
+const labelSpace = 4
+
+// place positions the label next to the arrow without overlapping
+// it.
+func (l *ArrowLabel) place(arrow *Arrow) {
+	p, dir := arrow.anchor(l.At)
+	// normal pointing up or right
+	n := xy.Vector{X: dir.Y, Y: -dir.X}
+	if n.Y > 0 || (n.Y == 0 && n.X < 0) {
+		n = n.Scale(-1)
+	}
+	if l.Below {
+		n = n.Scale(-1)
+	}
+	var (
+		w, h = float64(l.Width()), float64(l.Height())
+		// half the label measured along each direction
+		side  = math.Abs(n.X)*w/2 + math.Abs(n.Y)*h/2
+		along = math.Abs(dir.X)*w/2 + math.Abs(dir.Y)*h/2
+	)
+	if l.At != AtMiddle {
+		// step away from head or tail
+		p = p.Add(dir.Scale(along + 2*labelSpace))
+	}
+	p = p.Add(n.Scale(side + labelSpace))
+	pos := p.Add(xy.Vector{X: -w / 2, Y: -h / 2}).Position()
+	l.SetX(pos.X)
+	l.SetY(pos.Y)
+}
+
+// anchor returns the position of the anchor and the unit direction
+// of the arrow there, pointing into the arrow for start and end.
+func (arrow *Arrow) anchor(at Anchor) (xy.Point, xy.Vector) {
+	points := make([]xy.Point, 0)
+	for _, p := range arrow.Points() {
+		points = append(points, p.Point())
+	}
+	n := len(points)
+	switch at {
+	case AtStart:
+		return points[0], direction(points[0], points[1])
+	case AtEnd:
+		return points[n-1], direction(points[n-1], points[n-2])
+	}
+	// middle of the total length
+	var total float64
+	for i := 1; i < n; i++ {
+		total += points[i-1].Distance(points[i])
+	}
+	left := total / 2
+	for i := 1; i < n; i++ {
+		a, b := points[i-1], points[i]
+		l := a.Distance(b)
+		if l >= left && l > 0 {
+			dir := direction(a, b)
+			return a.Add(dir.Scale(left)), dir
+		}
+		left -= l
+	}
+	return points[0], direction(points[0], points[n-1])
+}
+
+// direction returns the unit vector from a to b, pointing right if
+// they are equal.
+func direction(a, b xy.Point) xy.Vector {
+	v := b.Sub(a).Normalize()
+	if v.Len() == 0 {
+		return xy.Vector{X: 1}
+	}
+	return v
+}
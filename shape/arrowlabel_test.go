@@ -0,0 +1,80 @@
+package shape
+
+import (
+	"testing"
+
+	"github.com/gregoryv/asserter"
+	"github.com/gregoryv/go-design/xy"
+)
+
+func TestArrow_AddLabel(t *testing.T) {
+	var (
+		right = NewArrow(20, 40, 180, 40)
+		down  = NewArrow(60, 80, 60, 220)
+		diag  = NewArrow(120, 80, 220, 200)
+		svg   = newSvg(260, 240, right, down, diag)
+	)
+	for _, a := range []*Arrow{right, down, diag} {
+		a.AddLabel(AtStart, "start")
+		a.AddLabel(AtMiddle, "middle")
+		a.AddLabel(AtEnd, "end")
+		a.AddLabel(AtEnd, "0..1").Below = true
+	}
+	writeSvgTo(t, "testdata/arrow_labels.svg", svg)
+
+	assert := asserter.New(t)
+	for _, a := range []*Arrow{right, down, diag} {
+		for _, l := range a.Labels {
+			assert(!crosses(a, l)).Errorf("%v overlaps %v", l.Label, a)
+		}
+	}
+	start, mid, end, below := right.Labels[0], right.Labels[1], right.Labels[2], right.Labels[3]
+	assert(start.Pos.Y+start.Height() <= 40).Errorf("%v not above", start.Label)
+	assert(below.Pos.Y >= 40).Errorf("%v not below", below.Label)
+	assert(start.Pos.X >= 20).Errorf("%v before start", start.Label)
+	assert(end.Pos.X+end.Width() <= 180).Errorf("%v after end", end.Label)
+	cx := mid.Pos.X + mid.Width()/2
+	assert(cx == 100).Errorf("%v not centered, %v", mid.Label, cx)
+	assert(down.Labels[1].Pos.X >= 60).Error("label not right of vertical arrow")
+	// labels follow the arrow
+	right.SetY(100)
+	right.PlaceLabels()
+	assert(start.Pos.Y > 40).Error("label did not follow arrow")
+}
+
+func TestArrow_AddLabel_sizeExcludesLabels(t *testing.T) {
+	a := NewArrow(0, 0, 100, 0)
+	l := a.AddLabel(AtMiddle, "a long label above the arrow")
+	assert := asserter.New(t)
+	assert().Equals(a.Width(), 100)
+	assert().Equals(a.Height(), 0)
+	x, y := l.Position()
+	assert(x == 0 && y == 0).Errorf("label moved by size to %v,%v", x, y)
+}
+
+func TestArrow_AddLabel_waypoints(t *testing.T) {
+	a := NewArrow(0, 0, 100, 200)
+	a.Waypoints = []xy.Position{{X: 100, Y: 0}}
+	mid := a.AddLabel(AtMiddle, "x")
+	a.PlaceLabels()
+	assert := asserter.New(t)
+	assert(mid.Pos.X > 100).Errorf("%v not right of corner", mid.Label)
+}
+
+// crosses returns true if any segment of the arrow passes through
+// the label.
+func crosses(a *Arrow, l *ArrowLabel) bool {
+	b := boxOf(l)
+	points := a.Points()
+	for i, p := range points[1:] {
+		q := points[i]
+		for s := 0.0; s <= 1; s += 0.01 {
+			x := q.X + int(s*float64(p.X-q.X))
+			y := q.Y + int(s*float64(p.Y-q.Y))
+			if b.inside(x, y) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -15,6 +15,8 @@ func NewCircle(radius int) *Circle {
 }
 
 type Circle struct {
+	Hyperlink
+
 	pos    xy.Position // top left
 	Radius int
 	class  string
@@ -43,13 +45,12 @@ func (c *Circle) WriteSvg(out io.Writer) error {
 	x, y := c.Position()
 	x += c.Radius
 	y += c.Radius
-	w.printf(
-		`<circle class="%s" cx="%v" cy="%v" r="%v" />\n`,
-		c.class, x, y, c.Radius,
+	w.empty("circle",
+		attr{"class", c.class}, attr{"cx", x}, attr{"cy", y}, attr{"r", c.Radius},
 	)
 	return *err
 }
 
 func (c *Circle) Edge(start xy.Position) xy.Position {
-	return boxEdge(start, c)
+	return circleEdge(start, c, c.Radius)
 }
@@ -19,13 +19,19 @@ func NewComponent(title string) *Component {
 }
 
 type Component struct {
+	Hyperlink
+
 	X, Y  int
 	Title string
 
 	Font  Font
 	Pad   Padding
+	Align TextAlign
 	class string
 
+	// minimum size
+	width, height int
+
 	//smallBoxWidth
 	sbWidth  int
 	sbHeight int
@@ -43,27 +49,33 @@ func (r *Component) SetClass(c string)    { r.class = c }
 
 func (r *Component) WriteSvg(out io.Writer) error {
 	w, err := newTagPrinter(out)
-	w.printf(
-		`<rect class="%s" x="%v" y="%v" width="%v" height="%v"/>`,
-		r.class, r.X, r.Y, r.Width(), r.Height())
-	w.printf("\n")
+	w.empty("rect",
+		attr{"class", r.class},
+		attr{"x", r.X}, attr{"y", r.Y},
+		attr{"width", r.Width()}, attr{"height", r.Height()},
+	)
 	// small boxes
-	w.printf(
-		`<rect class="%s" x="%v" y="%v" width="%v" height="%v"/>`,
-		r.class, r.X-r.sbWidth/2, r.Y+r.sbHeight, r.sbWidth, r.sbHeight)
-	w.printf(
-		`<rect class="%s" x="%v" y="%v" width="%v" height="%v"/>`,
-		r.class, r.X-r.sbWidth/2, r.Y+r.Height()-r.sbHeight*2, r.sbWidth, r.sbHeight)
+	for _, y := range []int{r.Y + r.sbHeight, r.Y + r.Height() - r.sbHeight*2} {
+		w.empty("rect",
+			attr{"class", r.class},
+			attr{"x", r.X - r.sbWidth/2}, attr{"y", y},
+			attr{"width", r.sbWidth}, attr{"height", r.sbHeight},
+		)
+	}
 
 	r.title().WriteSvg(w)
 	return *err
 }
 
 func (r *Component) title() *Label {
+	dx, dy := r.Align.offset(
+		r.Width()-r.contentWidth(),
+		r.Height()-boxHeight(r.Font, r.Pad, 1),
+	)
 	return &Label{
 		Pos: xy.Position{
-			r.X + r.Pad.Left + r.sbWidth/2,
-			r.Y + r.Pad.Top/2,
+			X: r.X + r.Pad.Left + r.sbWidth/2 + dx,
+			Y: r.Y + r.Pad.Top/2 + dy,
 		},
 		Font:  r.Font,
 		Text:  r.Title,
@@ -74,14 +86,27 @@ func (r *Component) title() *Label {
 func (r *Component) SetFont(f Font)         { r.Font = f }
 func (r *Component) SetTextPad(pad Padding) { r.Pad = pad }
 
+// SetTextAlign aligns the title within the component.
+func (r *Component) SetTextAlign(h, v Alignment) { r.Align = TextAlign{h, v} }
+
 func (r *Component) Height() int {
-	return boxHeight(r.Font, r.Pad, 1)
+	return maxInt(r.height, boxHeight(r.Font, r.Pad, 1))
 }
 
 func (r *Component) Width() int {
+	return maxInt(r.width, r.contentWidth())
+}
+
+func (r *Component) contentWidth() int {
 	return boxWidth(r.Font, r.Pad, r.Title) + r.sbWidth/2
 }
 
+// SetWidth sets the minimum width of the component.
+func (r *Component) SetWidth(w int) { r.width = w }
+
+// SetHeight sets the minimum height of the component.
+func (r *Component) SetHeight(h int) { r.height = h }
+
 // Edge returns intersecting position of a line starting at start and
 // pointing to the components center.
 func (r *Component) Edge(start xy.Position) xy.Position {
@@ -0,0 +1,300 @@
+package shape
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// NewConstraints returns an empty set of placement constraints.
+func NewConstraints() *Constraints {
+	return &Constraints{
+		rules: make([]rule, 0),
+	}
+}
+
+// Constraints declare how shapes are placed relative to each other.
+// Unlike the Adjuster nothing is moved until Solve is called, so the
+// order in which constraints are added does not matter.
+//
+// Solve places each constrained shape at the smallest non-negative
+// coordinate satisfying all constraints on that axis. Shapes without
+// constraints on an axis keep their position on it.
+type Constraints struct {
+	rules []rule
+}
+
+// rule is a difference constraint, to - from >= min on one axis. Exact
+// rules also require to - from <= min. Min is calculated when solved
+// as it may depend on the size of shapes.
+type rule struct {
+	vertical bool
+	from, to Shape // from is nil for the origin
+	min      func() int
+	exact    bool
+	desc     string
+}
+
+// px returns a constant minimum.
+func px(v int) func() int { return func() int { return v } }
+
+func (r rule) String() string { return r.desc }
+
+func (c *Constraints) add(r rule) {
+	c.rules = append(c.rules, r)
+}
+
+// At fixes the top left corner of s at x, y.
+func (c *Constraints) At(s Shape, x, y int) {
+	desc := fmt.Sprintf("%v at %v,%v", s, x, y)
+	c.add(rule{to: s, min: px(x), exact: true, desc: desc})
+	c.add(rule{vertical: true, to: s, min: px(y), exact: true, desc: desc})
+}
+
+// RightOf places s right of o with at least gap pixels between them.
+func (c *Constraints) RightOf(s, o Shape, gap int) {
+	c.add(rule{
+		from: o, to: s, min: func() int { return o.Width() + gap },
+		desc: fmt.Sprintf("%v right of %v, gap >= %v", s, o, gap),
+	})
+}
+
+// LeftOf places s left of o with at least gap pixels between them.
+func (c *Constraints) LeftOf(s, o Shape, gap int) {
+	c.add(rule{
+		from: s, to: o, min: func() int { return s.Width() + gap },
+		desc: fmt.Sprintf("%v left of %v, gap >= %v", s, o, gap),
+	})
+}
+
+// Below places s below o with at least gap pixels between them.
+func (c *Constraints) Below(s, o Shape, gap int) {
+	c.add(rule{
+		vertical: true, from: o, to: s,
+		min:  func() int { return o.Height() + gap },
+		desc: fmt.Sprintf("%v below %v, gap >= %v", s, o, gap),
+	})
+}
+
+// Above places s above o with at least gap pixels between them.
+func (c *Constraints) Above(s, o Shape, gap int) {
+	c.add(rule{
+		vertical: true, from: s, to: o,
+		min:  func() int { return s.Height() + gap },
+		desc: fmt.Sprintf("%v above %v, gap >= %v", s, o, gap),
+	})
+}
+
+// Inside keeps s within the bounding box of outer with at least pad
+// pixels to each side.
+func (c *Constraints) Inside(s, outer Shape, pad int) {
+	desc := fmt.Sprintf("%v inside %v, pad %v", s, outer, pad)
+	c.add(rule{from: outer, to: s, min: px(pad), desc: desc})
+	c.add(rule{
+		from: s, to: outer,
+		min:  func() int { return s.Width() + pad - outer.Width() },
+		desc: desc,
+	})
+	c.add(rule{vertical: true, from: outer, to: s, min: px(pad), desc: desc})
+	c.add(rule{
+		vertical: true, from: s, to: outer,
+		min:  func() int { return s.Height() + pad - outer.Height() },
+		desc: desc,
+	})
+}
+
+// VAlignCenter keeps the shapes on the same vertical center line.
+func (c *Constraints) VAlignCenter(shapes ...Shape) {
+	c.align(false, "center-x", shapes, func(s Shape) int { return s.Width() / 2 })
+}
+
+// VAlignLeft keeps the left side of the shapes on the same line.
+func (c *Constraints) VAlignLeft(shapes ...Shape) {
+	c.align(false, "left", shapes, func(Shape) int { return 0 })
+}
+
+// VAlignRight keeps the right side of the shapes on the same line.
+func (c *Constraints) VAlignRight(shapes ...Shape) {
+	c.align(false, "right", shapes, func(s Shape) int { return s.Width() })
+}
+
+// HAlignCenter keeps the shapes on the same horizontal center line.
+func (c *Constraints) HAlignCenter(shapes ...Shape) {
+	c.align(true, "center-y", shapes, func(s Shape) int { return s.Height() / 2 })
+}
+
+// HAlignTop keeps the top of the shapes on the same line.
+func (c *Constraints) HAlignTop(shapes ...Shape) {
+	c.align(true, "top", shapes, func(Shape) int { return 0 })
+}
+
+// HAlignBottom keeps the bottom of the shapes on the same line.
+func (c *Constraints) HAlignBottom(shapes ...Shape) {
+	c.align(true, "bottom", shapes, func(s Shape) int { return s.Height() })
+}
+
+// align adds exact rules so that offset of each shape is on the same
+// line.
+func (c *Constraints) align(vertical bool, line string, shapes []Shape, offset func(Shape) int) {
+	if len(shapes) < 2 {
+		return
+	}
+	names := make([]string, len(shapes))
+	for i, s := range shapes {
+		names[i] = fmt.Sprint(s)
+	}
+	desc := fmt.Sprintf("%s share %s", strings.Join(names, ", "), line)
+	first := shapes[0]
+	for _, s := range shapes[1:] {
+		s := s
+		c.add(rule{
+			vertical: vertical, from: first, to: s,
+			min:   func() int { return offset(first) - offset(s) },
+			exact: true,
+			desc:  desc,
+		})
+	}
+}
+
+// Solve moves the shapes to satisfy all constraints. An error
+// describing a conflicting constraint is returned if they cannot all
+// be satisfied, shapes are then left where they were. Containers are
+// moved before the shapes inside them, as their children move with
+// them.
+func (c *Constraints) Solve() error {
+	x, err := c.solve(false)
+	if err != nil {
+		return err
+	}
+	y, err := c.solve(true)
+	if err != nil {
+		return err
+	}
+	for _, s := range c.shapes() {
+		nx, found := x[s]
+		if !found {
+			nx = coord(s, false)
+		}
+		ny, found := y[s]
+		if !found {
+			ny = coord(s, true)
+		}
+		MoveTo(s, nx, ny)
+	}
+	return nil
+}
+
+// shapes returns the constrained shapes in the order they were first
+// constrained, shapes inside containers after the containers.
+func (c *Constraints) shapes() []Shape {
+	res := make([]Shape, 0)
+	seen := make(map[Shape]bool)
+	for _, r := range c.rules {
+		for _, s := range []Shape{r.from, r.to} {
+			if s != nil && !seen[s] {
+				seen[s] = true
+				res = append(res, s)
+			}
+		}
+	}
+	depth := make(map[Shape]int)
+	for _, s := range res {
+		for _, outer := range res {
+			if encloses(outer, s) {
+				depth[s]++
+			}
+		}
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		return depth[res[i]] < depth[res[j]]
+	})
+	return res
+}
+
+// encloses returns true if s is a child of the container outer, at
+// any depth.
+func encloses(outer, s Shape) bool {
+	c, ok := outer.(*Container)
+	if !ok {
+		return false
+	}
+	for _, child := range c.Children {
+		if child == s || encloses(child, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// solve returns the coordinate of each shape constrained on the
+// given axis.
+func (c *Constraints) solve(vertical bool) (map[Shape]int, error) {
+	type edge struct {
+		from, to Shape
+		w        int
+		r        rule
+	}
+	var (
+		edges = make([]edge, 0)
+		pos   = make(map[Shape]int)
+	)
+	// the origin is a nil shape
+	pos[nil] = 0
+	for _, r := range c.rules {
+		if r.vertical != vertical {
+			continue
+		}
+		min := r.min()
+		edges = append(edges, edge{r.from, r.to, min, r})
+		if r.exact {
+			edges = append(edges, edge{r.to, r.from, -min, r})
+		}
+		for _, s := range []Shape{r.from, r.to} {
+			if _, found := pos[s]; !found {
+				pos[s] = 0 // not placed at negative coordinates
+			}
+		}
+	}
+	// longest paths, any change after len(pos) rounds is a conflict
+	for i := 0; i <= len(pos); i++ {
+		changed := false
+		for _, e := range edges {
+			if v := pos[e.from] + e.w; v > pos[e.to] {
+				if i == len(pos) || e.to == nil {
+					return nil, fmt.Errorf("conflicting constraint: %v", e.r)
+				}
+				pos[e.to] = v
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+	delete(pos, nil)
+	return pos, nil
+}
+
+// coord returns the x or y coordinate of s.
+func coord(s Shape, vertical bool) int {
+	x, y := s.Position()
+	if vertical {
+		return y
+	}
+	return x
+}
+
+// MoveTo moves s so its position is x, y. Some shapes, e.g. diamonds,
+// are not positioned at the given coordinates by SetX and SetY so the
+// difference is corrected.
+func MoveTo(s Shape, x, y int) {
+	s.SetX(x)
+	s.SetY(y)
+	gx, gy := s.Position()
+	if gx != x {
+		s.SetX(x + x - gx)
+	}
+	if gy != y {
+		s.SetY(y + y - gy)
+	}
+}
@@ -0,0 +1,124 @@
+package shape
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gregoryv/asserter"
+)
+
+func TestConstraints_Solve(t *testing.T) {
+	var (
+		a   = NewRect("a")
+		b   = NewRect("b is wider")
+		c   = NewRect("c")
+		d   = NewDecision()
+		svg = newSvg(300, 200, a, b, c, d)
+		con = NewConstraints()
+	)
+	c.SetY(150) // unconstrained on y
+	// order does not matter
+	con.VAlignCenter(a, b, d)
+	con.Below(b, a, 30)
+	con.RightOf(c, b, 20)
+	con.Below(d, b, 20)
+	con.At(a, 40, 10)
+	err := con.Solve()
+	writeSvgTo(t, "testdata/constraints.svg", svg)
+
+	assert := asserter.New(t)
+	assert(err == nil).Fatal(err)
+	ax, ay := a.Position()
+	assert(ax == 40 && ay == 10).Errorf("a at %v,%v", ax, ay)
+	_, by := b.Position()
+	assert(by == ay+a.Height()+30).Errorf("b at y %v", by)
+	for _, s := range []Shape{b, d} {
+		cx := center(s).X
+		assert(intAbs(cx-center(a).X) <= 1).Errorf("%v not centered %v", s, cx)
+	}
+	bx, _ := b.Position()
+	cx, cy := c.Position()
+	assert(cx == bx+b.Width()+20).Errorf("c at x %v", cx)
+	assert(cy == 150).Errorf("c moved on y to %v", cy)
+	_, dy := d.Position()
+	assert(dy == by+b.Height()+20).Errorf("diamond at y %v", dy)
+}
+
+func TestConstraints_conflict(t *testing.T) {
+	var (
+		a   = NewRect("a")
+		b   = NewRect("b")
+		con = NewConstraints()
+	)
+	con.At(a, 10, 10)
+	con.At(b, 20, 10)
+	con.RightOf(b, a, 30)
+	err := con.Solve()
+	assert := asserter.New(t)
+	assert(err != nil).Fatal("expected conflict")
+	assert(strings.Contains(err.Error(), "conflicting")).Error(err)
+	x, _ := b.Position()
+	assert(x == 0).Errorf("moved on conflict to %v", x)
+
+	// cycle
+	con = NewConstraints()
+	con.RightOf(a, b, 1)
+	con.RightOf(b, a, 1)
+	assert(con.Solve() != nil).Error("cycle not reported")
+}
+
+func TestConstraints_Inside(t *testing.T) {
+	var (
+		outer = NewRect("a rather wide box")
+		inner = NewDot(4)
+		con   = NewConstraints()
+	)
+	con.At(outer, 10, 10)
+	con.Inside(inner, outer, 4)
+	con.HAlignBottom(inner, outer)
+	assert := asserter.New(t)
+	assert(con.Solve() != nil).Error("bottom aligned with padding")
+
+	con = NewConstraints()
+	con.At(outer, 10, 10)
+	con.Inside(inner, outer, 0)
+	con.VAlignRight(outer, inner)
+	err := con.Solve()
+	assert(err == nil).Fatal(err)
+	x, y := inner.Position()
+	assert(x+inner.Width() == 10+outer.Width()).Error("not right aligned", x)
+	assert(y == 10).Error("not inside", y)
+}
+
+func TestConstraints_Solve_container(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		var (
+			c   = NewContainer("pkg")
+			r   = NewRect("inside")
+			con = NewConstraints()
+		)
+		c.Place(r)
+		con.At(c, 100, 100)
+		con.Inside(r, c, 20)
+		err := con.Solve()
+		assert := asserter.New(t)
+		assert(err == nil).Fatal(err)
+		x, y := r.Position()
+		assert(x == 120 && y == 120).Fatalf("run %v: child at %v,%v", i, x, y)
+	}
+}
+
+func TestConstraints_Solve_sizeWhenSolved(t *testing.T) {
+	var (
+		a   = NewRect("a")
+		b   = NewRect("b")
+		con = NewConstraints()
+	)
+	con.At(a, 0, 0)
+	con.RightOf(b, a, 10)
+	a.SetWidth(200)
+	con.Solve()
+	x, _ := b.Position()
+	assert := asserter.New(t)
+	assert(x == 210).Errorf("b at x %v", x)
+}
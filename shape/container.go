@@ -0,0 +1,160 @@
+package shape
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/gregoryv/go-design/xy"
+)
+
+// NewContainer returns an empty container with the given title.
+func NewContainer(title string) *Container {
+	return &Container{
+		Title:    title,
+		Children: make([]Shape, 0),
+		Font:     DefaultFont,
+		TextPad:  DefaultTextPad,
+		Pad:      Padding{Left: 20, Top: 20, Right: 20, Bottom: 20},
+		class:    "container",
+	}
+}
+
+// Container is a titled frame around other shapes, e.g. a package,
+// namespace or swimlane. It grows to fit its children which move with
+// it.
+type Container struct {
+	Hyperlink
+
+	X, Y  int
+	Title string
+	// Tab writes the title in a tab above the frame, as for
+	// packages, instead of in a header inside it.
+	Tab      bool
+	Children []Shape
+
+	Font    Font
+	TextPad Padding // around the title
+	Pad     Padding // around children
+	class   string
+}
+
+func (c *Container) String() string {
+	return fmt.Sprintf("Container %q", c.Title)
+}
+
+// Place adds the shapes to the container at the top left of its
+// content area. Use the adjuster to position them relative to each
+// other.
+func (c *Container) Place(s ...Shape) *Adjuster {
+	x, y := c.origin()
+	for _, s := range s {
+		s.SetX(x)
+		s.SetY(y)
+		c.Children = append(c.Children, s)
+	}
+	return NewAdjuster(s...)
+}
+
+// origin returns the top left position of the content area.
+func (c *Container) origin() (int, int) {
+	return c.X + c.Pad.Left, c.Y + c.header() + c.Pad.Top
+}
+
+// header returns the height of the title header or tab.
+func (c *Container) header() int {
+	return boxHeight(c.Font, c.TextPad, 1)
+}
+
+func (c *Container) Position() (int, int) { return c.X, c.Y }
+
+// SetX moves the container and its children.
+func (c *Container) SetX(x int) {
+	diff := x - c.X
+	c.X = x
+	for _, s := range c.Children {
+		sx, _ := s.Position()
+		s.SetX(sx + diff)
+	}
+}
+
+// SetY moves the container and its children.
+func (c *Container) SetY(y int) {
+	diff := y - c.Y
+	c.Y = y
+	for _, s := range c.Children {
+		_, sy := s.Position()
+		s.SetY(sy + diff)
+	}
+}
+
+// Width returns the width needed to fit the title and all children.
+func (c *Container) Width() int {
+	w := boxWidth(c.Font, c.TextPad, c.Title)
+	for _, s := range c.Children {
+		x, _ := s.Position()
+		w = maxInt(w, x+s.Width()+c.Pad.Right-c.X)
+	}
+	return maxInt(w, c.Pad.Left+c.Pad.Right)
+}
+
+// Height returns the height needed to fit the title and all
+// children.
+func (c *Container) Height() int {
+	h := c.header() + c.Pad.Top + c.Pad.Bottom
+	for _, s := range c.Children {
+		_, y := s.Position()
+		h = maxInt(h, y+s.Height()+c.Pad.Bottom-c.Y)
+	}
+	return h
+}
+
+func (c *Container) Direction() Direction  { return LR }
+func (c *Container) SetClass(class string) { c.class = class }
+func (c *Container) SetFont(f Font)        { c.Font = f }
+func (c *Container) SetTextPad(p Padding)  { c.TextPad = p }
+
+func (c *Container) WriteSvg(out io.Writer) error {
+	w, err := newTagPrinter(out)
+	header := c.header()
+	rect := func(y, width, height int) {
+		w.empty("rect",
+			attr{"class", c.class},
+			attr{"x", c.X}, attr{"y", y},
+			attr{"width", width}, attr{"height", height},
+		)
+	}
+	if c.Tab {
+		rect(c.Y, boxWidth(c.Font, c.TextPad, c.Title), header)
+		rect(c.Y+header, c.Width(), c.Height()-header)
+	} else {
+		rect(c.Y, c.Width(), c.Height())
+		w.empty("line",
+			attr{"class", c.class + "-line"},
+			attr{"x1", c.X}, attr{"y1", c.Y + header},
+			attr{"x2", c.X + c.Width()}, attr{"y2", c.Y + header},
+		)
+	}
+	c.title().WriteSvg(w)
+	for _, s := range c.Children {
+		writeShape(w, s)
+	}
+	return *err
+}
+
+func (c *Container) title() *Label {
+	return &Label{
+		Pos: xy.Position{
+			X: c.X + c.TextPad.Left,
+			Y: c.Y + c.TextPad.Top/2,
+		},
+		Font:  c.Font,
+		Text:  c.Title,
+		class: c.class + "-title",
+	}
+}
+
+// Edge returns intersecting position of a line starting at start and
+// pointing to the container center.
+func (c *Container) Edge(start xy.Position) xy.Position {
+	return boxEdge(start, c)
+}
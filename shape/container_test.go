@@ -0,0 +1,66 @@
+package shape
+
+import (
+	"testing"
+
+	"github.com/gregoryv/asserter"
+)
+
+func TestContainer(t *testing.T) {
+	testShape(t, NewContainer("x"))
+}
+
+func TestContainer_children(t *testing.T) {
+	var (
+		pkg    = NewContainer("package shape")
+		a      = NewRect("a")
+		b      = NewRect("b")
+		lane   = NewContainer("lane")
+		c      = NewRect("c in a lane")
+		assert = asserter.New(t)
+	)
+	pkg.Tab = true
+	pkg.Place(a)
+	pkg.Place(b).RightOf(a)
+	lane.Place(c)
+	NewAdjuster(pkg).At(10, 10)
+	NewAdjuster(lane).RightOf(pkg)
+	svg := newSvg(400, 200, pkg, lane,
+		NewArrowBetween(a, b),
+		NewArrowBetween(b, c),
+	)
+	writeSvgTo(t, "testdata/container.svg", svg)
+
+	// grows to fit children
+	outer := boxOf(pkg)
+	for _, s := range []Shape{a, b} {
+		assert(outer.encloses(boxOf(s))).Errorf("%v not within %v", s, pkg)
+	}
+	assert(boxOf(lane).encloses(boxOf(c))).Error("c outside lane")
+	ax, ay := a.Position()
+	assert(ax == 10+pkg.Pad.Left).Errorf("child x %v", ax)
+	assert(ay > 10+pkg.Pad.Top).Errorf("child y %v below header", ay)
+
+	// children move with the container
+	pkg.SetX(50)
+	pkg.SetY(60)
+	x, y := a.Position()
+	assert(x == ax+40 && y == ay+50).Errorf("child at %v,%v", x, y)
+}
+
+func TestContainer_route(t *testing.T) {
+	var (
+		pkg   = NewContainer("pkg")
+		a     = NewRect("a")
+		b     = NewRect("b")
+		block = NewRect("block")
+	)
+	pkg.Place(a)
+	pkg.Place(block).RightOf(a)
+	pkg.Place(b).RightOf(block)
+	svg := newSvg(300, 200, pkg)
+	arrow := NewArrowBetween(a, b)
+	svg.Router().Route(arrow)
+	assert := asserter.New(t)
+	assert(len(arrow.Waypoints) > 0).Error("not routed around child")
+}
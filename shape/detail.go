@@ -0,0 +1,175 @@
+package shape
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Detail controls how much of a reflected type is shown in a
+// record. The zero value shows names only.
+type Detail struct {
+	// Types shows the type of each field, e.g. "Title string"
+	Types bool
+
+	// Signatures shows method parameters and results,
+	// e.g. "ServeHTTP(ResponseWriter, *Request)"
+	Signatures bool
+
+	// Pkg is the import path type names are shortened relative
+	// to. Types declared in Pkg are written without package name.
+	Pkg string
+
+	// Visibility includes unexported members and prefixes all
+	// members with UML visibility markers, + for exported and -
+	// for unexported. Note that reflection only reveals unexported
+	// methods of interfaces.
+	Visibility bool
+
+	// Embedded shows embedded fields by their type in a distinct
+	// style.
+	Embedded bool
+
+	// MaxLen truncates fields and methods longer than MaxLen
+	// characters, 0 means no limit.
+	MaxLen int
+
+	// params maps type arguments to type parameter names of
+	// generic records
+	params map[string]string
+}
+
+// shows returns true if a member with the given name should be
+// visible.
+func (d Detail) shows(name string) bool {
+	return d.Visibility || isPublic(name)
+}
+
+func (d Detail) field(f reflect.StructField) string {
+	var txt string
+	switch {
+	case f.Anonymous && d.Embedded:
+		txt = d.typeName(f.Type)
+	case d.Types:
+		txt = f.Name + " " + d.typeName(f.Type)
+	default:
+		txt = f.Name
+	}
+	return d.truncate(d.marker(f.Name) + txt)
+}
+
+func (d Detail) marker(name string) string {
+	switch {
+	case !d.Visibility:
+		return ""
+	case isPublic(name):
+		return "+"
+	}
+	return "-"
+}
+
+// method returns the method name with optional signature. Methods
+// of non interface types include the receiver as first argument
+// which is skipped.
+func (d Detail) method(m reflect.Method, t reflect.Type) string {
+	name := d.marker(m.Name) + m.Name
+	if !d.Signatures {
+		return name + "()"
+	}
+	var skip int
+	if t.Kind() != reflect.Interface {
+		skip = 1
+	}
+	return d.truncate(name + d.signature(m.Type, skip))
+}
+
+func (d Detail) signature(t reflect.Type, skip int) string {
+	in := make([]string, 0, t.NumIn())
+	for i := skip; i < t.NumIn(); i++ {
+		p := t.In(i)
+		if t.IsVariadic() && i == t.NumIn()-1 {
+			in = append(in, "..."+d.typeName(p.Elem()))
+			continue
+		}
+		in = append(in, d.typeName(p))
+	}
+	sig := "(" + strings.Join(in, ", ") + ")"
+	switch t.NumOut() {
+	case 0:
+	case 1:
+		sig += " " + d.typeName(t.Out(0))
+	default:
+		out := make([]string, t.NumOut())
+		for i := range out {
+			out[i] = d.typeName(t.Out(i))
+		}
+		sig += " (" + strings.Join(out, ", ") + ")"
+	}
+	return sig
+}
+
+// aliases of predeclared types as they are usually written, e.g.
+// []byte rather than []uint8
+var aliases = map[string]string{
+	"uint8": "byte",
+	"int32": "rune",
+}
+
+// typeName returns the name of t as written in Go source, with
+// package names left out for types declared in d.Pkg.
+func (d Detail) typeName(t reflect.Type) string {
+	if p, found := d.params[argName(t)]; found {
+		return p
+	}
+	if alias, found := aliases[t.Name()]; found && t.PkgPath() == "" {
+		return alias
+	}
+	if t.Name() != "" {
+		if t.PkgPath() == "" || t.PkgPath() == d.Pkg {
+			return d.shortenArgs(t.Name())
+		}
+		return d.shortenArgs(t.String())
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		return "*" + d.typeName(t.Elem())
+	case reflect.Slice:
+		return "[]" + d.typeName(t.Elem())
+	case reflect.Array:
+		return fmt.Sprintf("[%v]%s", t.Len(), d.typeName(t.Elem()))
+	case reflect.Map:
+		return "map[" + d.typeName(t.Key()) + "]" + d.typeName(t.Elem())
+	case reflect.Chan:
+		switch t.ChanDir() {
+		case reflect.RecvDir:
+			return "<-chan " + d.typeName(t.Elem())
+		case reflect.SendDir:
+			return "chan<- " + d.typeName(t.Elem())
+		}
+		return "chan " + d.typeName(t.Elem())
+	case reflect.Func:
+		return "func" + d.signature(t, 0)
+	}
+	return t.String()
+}
+
+func (d Detail) truncate(s string) string {
+	r := []rune(s)
+	if d.MaxLen <= 0 || len(r) <= d.MaxLen {
+		return s
+	}
+	if d.MaxLen <= 3 {
+		return string(r[:d.MaxLen])
+	}
+	return string(r[:d.MaxLen-3]) + "..."
+}
+
+// methodName returns the name part of a method as written in a
+// record, e.g. "ServeHTTP" for "+ServeHTTP(ResponseWriter, *Request)".
+func methodName(m string) string {
+	m = strings.TrimLeft(m, "+-")
+	if i := strings.Index(m, "("); i != -1 {
+		return m[:i]
+	}
+	return m
+}
@@ -0,0 +1,109 @@
+package shape
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gregoryv/asserter"
+)
+
+func TestDetail_typeName(t *testing.T) {
+	cases := []struct {
+		d   Detail
+		v   interface{}
+		exp string
+	}{
+		{Detail{}, "", "string"},
+		{Detail{}, http.Request{}, "http.Request"},
+		{Detail{Pkg: "net/http"}, http.Request{}, "Request"},
+		{Detail{Pkg: "net/http"}, &http.Request{}, "*Request"},
+		{Detail{}, []*Record{}, "[]*shape.Record"},
+		{Detail{}, [2]int{}, "[2]int"},
+		{Detail{}, map[string][]byte{}, "map[string][]byte"},
+		{Detail{}, make(<-chan int), "<-chan int"},
+		{Detail{}, make(chan<- int), "chan<- int"},
+		{Detail{}, make(chan int), "chan int"},
+		{Detail{}, func(string, ...int) (int, error) { return 0, nil }, "func(string, ...int) (int, error)"},
+	}
+	assert := asserter.New(t)
+	for _, c := range cases {
+		got := c.d.typeName(reflect.TypeOf(c.v))
+		assert().Equals(got, c.exp)
+	}
+}
+
+func TestDetail_method(t *testing.T) {
+	handler := reflect.TypeOf((*http.Handler)(nil)).Elem()
+	rec := reflect.TypeOf(&Record{})
+	cases := []struct {
+		d    Detail
+		t    reflect.Type
+		name string
+		exp  string
+	}{
+		{Detail{}, handler, "ServeHTTP", "ServeHTTP()"},
+		{
+			Detail{Signatures: true, Pkg: "net/http"}, handler, "ServeHTTP",
+			"ServeHTTP(ResponseWriter, *Request)",
+		},
+		{
+			Detail{Signatures: true}, handler, "ServeHTTP",
+			"ServeHTTP(http.ResponseWriter, *http.Request)",
+		},
+		{Detail{Signatures: true}, rec, "WriteSvg", "WriteSvg(io.Writer) error"},
+		{Detail{Signatures: true, MaxLen: 10}, rec, "WriteSvg", "WriteSv..."},
+		{Detail{Signatures: true}, rec, "Position", "Position() (int, int)"},
+	}
+	assert := asserter.New(t)
+	for _, c := range cases {
+		m, _ := c.t.MethodByName(c.name)
+		got := c.d.method(m, c.t)
+		assert().Equals(got, c.exp)
+	}
+}
+
+func TestDetail_field(t *testing.T) {
+	f, _ := reflect.TypeOf(Record{}).FieldByName("Title")
+	assert := asserter.New(t)
+	assert().Equals(Detail{}.field(f), "Title")
+	assert().Equals(Detail{Types: true}.field(f), "Title string")
+	assert().Equals(Detail{Types: true, MaxLen: 2}.field(f), "Ti")
+}
+
+func TestNewStructRecord_withDetail(t *testing.T) {
+	rec := NewStructRecord(Record{}, Detail{Types: true, Signatures: true})
+	assert := asserter.New(t)
+	assert().Contains(strings.Join(rec.Fields, "\n"), "Title string")
+	assert().Contains(strings.Join(rec.Methods, "\n"), "WriteSvg(io.Writer) error")
+
+	rec = NewInterfaceRecord((*io.Writer)(nil), Detail{Signatures: true})
+	assert().Contains(strings.Join(rec.Methods, "\n"), "Write([]byte) (int, error)")
+	assert(!rec.HideMethod("Write()")).Error("other signature hidden")
+	assert(rec.HideMethod("Write([]byte) (int, error)")).Error("signature not hidden")
+	rec = NewInterfaceRecord((*io.Writer)(nil), Detail{Signatures: true})
+	assert(rec.HideMethod("Write")).Error("name only not hidden")
+}
+
+type embedding struct {
+	*Record
+	Shown  string
+	hidden int
+}
+
+func TestDetail_visibilityAndEmbedded(t *testing.T) {
+	assert := asserter.New(t)
+	rec := NewStructRecord(embedding{})
+	assert().Equals(strings.Join(rec.Fields, ","), "Record,Shown")
+
+	rec = NewStructRecord(embedding{}, Detail{Visibility: true, Embedded: true})
+	assert().Equals(strings.Join(rec.Fields, ","), "+*shape.Record,+Shown,-hidden")
+	assert(rec.HideMethod("String")).Error("marker compared when hiding")
+
+	buf := &bytes.Buffer{}
+	rec.WriteSvg(buf)
+	assert().Contains(buf.String(), `class="embedded-field"`)
+}
@@ -24,6 +24,8 @@ func NewDecision() *Diamond {
 }
 
 type Diamond struct {
+	Hyperlink
+
 	pos    xy.Position
 	width  int
 	height int
@@ -49,11 +51,20 @@ func (d *Diamond) WriteSvg(out io.Writer) error {
 	w2 := d.width / 2
 	h2 := d.height / 2
 	// the path is drawn from left to right
-	w.printf(`<path class="%s" d="M%v,%v l %v,%v %v,%v %v,%v %v,%v" />`,
-		d.class, x, y, w2, -h2, w2, h2, -w2, h2, -w2, -h2)
+	w.empty("path",
+		attr{"class", d.class},
+		attr{"d", fmt.Sprintf("M%v,%v l %v,%v %v,%v %v,%v %v,%v",
+			x, y, w2, -h2, w2, h2, -w2, h2, -w2, -h2)},
+	)
 	return *err
 }
 
 func (d *Diamond) Edge(start xy.Position) xy.Position {
-	return boxEdge(start, d)
+	x, y := d.pos.XY()
+	return polygonEdge(start, center(d), xy.Polygon{
+		{X: x, Y: y + d.height/2},
+		{X: x + d.width/2, Y: y},
+		{X: x + d.width, Y: y + d.height/2},
+		{X: x + d.width/2, Y: y + d.height},
+	})
 }
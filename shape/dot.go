@@ -15,6 +15,8 @@ func NewDot(radius int) *Dot {
 }
 
 type Dot struct {
+	Hyperlink
+
 	pos    xy.Position
 	Radius int
 	class  string
@@ -43,13 +45,12 @@ func (c *Dot) WriteSvg(out io.Writer) error {
 	x, y := c.Position()
 	x += c.Radius
 	y += c.Radius
-	w.printf(
-		`<circle class="%s" cx="%v" cy="%v" r="%v" />\n`,
-		c.class, x, y, c.Radius,
+	w.empty("circle",
+		attr{"class", c.class}, attr{"cx", x}, attr{"cy", y}, attr{"r", c.Radius},
 	)
 	return *err
 }
 
 func (c *Dot) Edge(start xy.Position) xy.Position {
-	return boxEdge(start, c)
+	return circleEdge(start, c, c.Radius)
 }
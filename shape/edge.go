@@ -0,0 +1,60 @@
+package shape
+
+import "github.com/gregoryv/go-design/xy"
+
+// circleEdge returns the position where a line from start to the
+// center of the circle drawn at s crosses it.
+func circleEdge(start xy.Position, s Box, radius int) xy.Position {
+	x, y := s.Position()
+	c := xy.Circle{
+		Center: xy.Position{X: x + radius, Y: y + radius},
+		Radius: radius,
+	}
+	hits := c.IntersectSegment(&xy.Line{Start: start, End: c.Center})
+	if len(hits) == 0 {
+		return boxEdge(start, s)
+	}
+	return hits[0]
+}
+
+// polygonEdge returns the position where a line from start to a
+// position inside the polygon first crosses it.
+func polygonEdge(start, inside xy.Position, p xy.Polygon) xy.Position {
+	hits := p.IntersectSegment(&xy.Line{Start: start, End: inside})
+	if len(hits) == 0 {
+		return inside
+	}
+	return hits[0]
+}
+
+// roundedEdge returns the position where a line from start to the
+// center of s crosses its bounding box with corners rounded by
+// radius.
+func roundedEdge(start xy.Position, s Box, radius int) xy.Position {
+	p := boxEdge(start, s)
+	b := boxOf(s)
+	// center of the corner circle p is closest to
+	c := p
+	switch {
+	case p.X < b.x1+radius:
+		c.X = b.x1 + radius
+	case p.X > b.x2-radius:
+		c.X = b.x2 - radius
+	default:
+		return p
+	}
+	switch {
+	case p.Y < b.y1+radius:
+		c.Y = b.y1 + radius
+	case p.Y > b.y2-radius:
+		c.Y = b.y2 - radius
+	default:
+		return p
+	}
+	corner := xy.Circle{Center: c, Radius: radius}
+	hits := corner.IntersectSegment(&xy.Line{Start: start, End: center(s)})
+	if len(hits) == 0 {
+		return p
+	}
+	return hits[0]
+}
@@ -0,0 +1,72 @@
+package shape
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gregoryv/asserter"
+	"github.com/gregoryv/go-design/xy"
+)
+
+func TestEdge_outlines(t *testing.T) {
+	var (
+		circle   = NewCircle(20)
+		dot      = NewDot(10)
+		exit     = NewExitDot()
+		decision = NewDecision()
+		state    = NewState("rounded state")
+		note     = NewNote("folded\nnote")
+		shapes   = []Shape{circle, dot, exit, decision, state, note}
+		svg      = newSvg(420, 240)
+		from     = xy.Position{X: 10, Y: 10}
+		assert   = asserter.New(t)
+	)
+	for i, s := range shapes {
+		s.SetX(60 + (i%3)*120)
+		s.SetY(100 + (i/3)*80)
+		svg.Append(s)
+	}
+	for _, s := range shapes {
+		to := s.(Edge).Edge(from)
+		svg.Append(NewArrow(from.X, from.Y, to.X, to.Y))
+	}
+	writeSvgTo(t, "testdata/arrow_edges.svg", svg)
+
+	onCircle := func(c Shape, radius int) {
+		t.Helper()
+		x, y := c.Position()
+		p := c.(Edge).Edge(from)
+		d := p.Distance(xy.Position{X: x + radius, Y: y + radius})
+		assert(math.Abs(d-float64(radius)) <= 1).Errorf(
+			"%v: %v is %v from center", c, p, d,
+		)
+	}
+	onCircle(circle, circle.Radius)
+	onCircle(dot, dot.Radius)
+	onCircle(exit, exit.Radius)
+
+	// diamond outline |dx|/w2 + |dy|/h2 = 1
+	p := decision.Edge(from)
+	c := center(decision)
+	w2, h2 := float64(decision.Width())/2, float64(decision.Height())/2
+	v := math.Abs(float64(p.X-c.X))/w2 + math.Abs(float64(p.Y-c.Y))/h2
+	assert(math.Abs(v-1) <= 0.2).Errorf("%v not on diamond outline", p)
+
+	// top left corner of a state is rounded
+	b := boxOf(state)
+	c = center(state)
+	p = state.Edge(xy.Position{X: 2*b.x1 - c.X, Y: 2*b.y1 - c.Y})
+	assert(p.X > b.x1 && p.Y > b.y1).Errorf("%v on square corner", p)
+
+	// folded corner of a note
+	b = boxOf(note)
+	p = note.Edge(xy.Position{X: b.x2 + 50, Y: b.y1 - 50})
+	assert(p.X < b.x2 && p.Y > b.y1).Errorf("%v on folded corner", p)
+}
+
+func TestTriangle_Edge(t *testing.T) {
+	tri := NewTriangle(100, 100, "arrow-head")
+	p := tri.Edge(xy.Position{X: 200, Y: 100})
+	assert := asserter.New(t)
+	assert(p.Equals(xy.Position{X: 100, Y: 100})).Errorf("got %v", p)
+}
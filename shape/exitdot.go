@@ -15,6 +15,8 @@ func NewExitDot() *ExitDot {
 }
 
 type ExitDot struct {
+	Hyperlink
+
 	pos    xy.Position
 	Radius int
 	class  string
@@ -44,18 +46,16 @@ func (c *ExitDot) WriteSvg(out io.Writer) error {
 	x, y := c.Position()
 	x += c.Radius
 	y += c.Radius
-	w.printf(
-		`<circle class="%s" cx="%v" cy="%v" r="%v" />\n`,
-		c.class, x, y, c.Radius,
+	w.empty("circle",
+		attr{"class", c.class}, attr{"cx", x}, attr{"cy", y}, attr{"r", c.Radius},
 	)
-	w.printf(
-		`<circle class="%s-dot" cx="%v" cy="%v" r="%v" />\n`,
-		c.class, x, y, c.Radius-4,
+	w.empty("circle",
+		attr{"class", c.class + "-dot"},
+		attr{"cx", x}, attr{"cy", y}, attr{"r", c.Radius - 4},
 	)
-
 	return *err
 }
 
 func (c *ExitDot) Edge(start xy.Position) xy.Position {
-	return boxEdge(start, c)
+	return circleEdge(start, c, c.Radius)
 }
@@ -0,0 +1,152 @@
+package shape
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// NewGenericRecord returns a record of a generic type declaration
+// based on one instantiation of it. The type parameters are given in
+// declaration order, e.g. "T any". Type arguments of obj are
+// replaced by the parameter names wherever they appear, so use
+// distinct type arguments not used otherwise in the type. For
+// interfaces obj is expected to be a nil pointer to the interface.
+func NewGenericRecord(obj interface{}, params []string, detail ...Detail) *Record {
+	t := reflect.TypeOf(obj)
+	kind := "struct"
+	if t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Interface {
+		t = t.Elem()
+		kind = "interface"
+	}
+	args := typeArgs(t)
+	if len(args) != len(params) {
+		panic(fmt.Sprintf(
+			"%v has %v type arguments, got %v parameters",
+			t, len(args), len(params),
+		))
+	}
+	d := firstDetail(detail)
+	d.params = make(map[string]string)
+	for i, p := range params {
+		name := strings.Fields(p)
+		if len(name) == 0 {
+			panic(fmt.Sprintf("%v has empty type parameter %v", t, i+1))
+		}
+		d.params[args[i]] = name[0]
+	}
+	rec := NewRecord(fmt.Sprintf(
+		"%s[%s] %s", GenericName(t), strings.Join(params, ", "), kind,
+	))
+	if kind == "struct" {
+		rec.addFields(t, d)
+		rec.addMethods(reflect.PtrTo(t), d)
+	} else {
+		rec.addMethods(t, d)
+	}
+	return rec
+}
+
+// NewConstraintRecord returns an interface record of a type
+// constraint with the given type set terms, e.g. "~int",
+// "~float64". Constraints cannot be reflected so they are described
+// by name.
+func NewConstraintRecord(name string, terms ...string) *Record {
+	rec := NewRecord(name + " interface")
+	rec.Fields = append(rec.Fields, terms...)
+	return rec
+}
+
+// GenericName returns the name of t without type arguments,
+// e.g. "List" for List[int].
+func GenericName(t reflect.Type) string {
+	name := t.Name()
+	if i := strings.Index(name, "["); i != -1 {
+		return name[:i]
+	}
+	return name
+}
+
+// TypeArgs returns the type arguments of an instantiated generic
+// type written relative to d.Pkg, e.g. ["string", "http.Header"]
+// for Pair[string, http.Header].
+func (d Detail) TypeArgs(t reflect.Type) []string {
+	args := typeArgs(t)
+	for i, arg := range args {
+		args[i] = qualified.ReplaceAllStringFunc(arg, d.shortenQualified)
+	}
+	return args
+}
+
+// typeArgs returns the type arguments of an instantiated generic
+// type as written by reflect, e.g. ["string", "net/http.Header"]
+// for Pair[string, http.Header].
+func typeArgs(t reflect.Type) []string {
+	name := t.Name()
+	i := strings.Index(name, "[")
+	if i == -1 || !strings.HasSuffix(name, "]") {
+		return []string{}
+	}
+	return splitArgs(name[i+1 : len(name)-1])
+}
+
+// splitArgs splits a comma separated list of type arguments, commas
+// within brackets are ignored.
+func splitArgs(s string) []string {
+	args := make([]string, 0)
+	var depth, start int
+	for i, c := range s {
+		switch c {
+		case '[', '(', '{':
+			depth++
+		case ']', ')', '}':
+			depth--
+		case ',':
+			if depth == 0 {
+				args = append(args, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(args, strings.TrimSpace(s[start:]))
+}
+
+// qualified matches package qualified identifiers, e.g. net/http.Header
+var qualified = regexp.MustCompile(`[\w./-]+\.\w+`)
+
+// shortenArgs returns name with type arguments written relative to
+// d.Pkg and replaced by type parameters if any.
+func (d Detail) shortenArgs(name string) string {
+	i := strings.Index(name, "[")
+	if i == -1 || !strings.HasSuffix(name, "]") {
+		return name
+	}
+	args := splitArgs(name[i+1 : len(name)-1])
+	for j, arg := range args {
+		if p, found := d.params[arg]; found {
+			args[j] = p
+			continue
+		}
+		args[j] = qualified.ReplaceAllStringFunc(arg, d.shortenQualified)
+	}
+	return name[:i] + "[" + strings.Join(args, ", ") + "]"
+}
+
+func (d Detail) shortenQualified(s string) string {
+	i := strings.LastIndex(s, ".")
+	path, name := s[:i], s[i+1:]
+	if path == d.Pkg {
+		return name
+	}
+	return path[strings.LastIndex(path, "/")+1:] + "." + name
+}
+
+// argName returns t as written by reflect when used as type
+// argument.
+func argName(t reflect.Type) string {
+	if t.Name() != "" && t.PkgPath() != "" {
+		return t.PkgPath() + "." + t.Name()
+	}
+	return t.String()
+}
@@ -0,0 +1,52 @@
+//go:build go1.18
+
+package shape
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gregoryv/asserter"
+)
+
+type pair[K comparable, V any] struct {
+	Key   K
+	Value V
+	Next  *pair[K, V]
+}
+
+func TestNewGenericRecord(t *testing.T) {
+	d := Detail{Types: true, Pkg: "github.com/gregoryv/go-design/shape"}
+	rec := NewGenericRecord(
+		pair[string, Shape]{}, []string{"K comparable", "V Shape"}, d,
+	)
+	assert := asserter.New(t)
+	assert().Equals(rec.Title, "pair[K comparable, V Shape] struct")
+	assert().Equals(strings.Join(rec.Fields, ","), "Key K,Value V,Next *pair[K, V]")
+
+	rec = NewStructRecord(pair[string, *Record]{}, d)
+	assert().Equals(rec.Title, "pair[string, *Record] struct")
+	args := Detail{}.TypeArgs(reflect.TypeOf(pair[int, *Record]{}))
+	assert().Equals(strings.Join(args, ","), "int,*shape.Record")
+
+	rec = NewConstraintRecord("Number", "~int", "~float64")
+	assert().Equals(len(rec.Fields), 2)
+
+	mustPanic(t, "parameter mismatch", func() {
+		NewGenericRecord(pair[int, int]{}, []string{"T"})
+	})
+	mustPanic(t, "empty parameter", func() {
+		NewGenericRecord(pair[int, int]{}, []string{"K", " "})
+	})
+}
+
+func mustPanic(t *testing.T, what string, fn func()) {
+	t.Helper()
+	defer func() {
+		if recover() == nil {
+			t.Error("should panic on", what)
+		}
+	}()
+	fn()
+}
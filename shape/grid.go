@@ -0,0 +1,229 @@
+package shape
+
+import "fmt"
+
+// NewGrid returns a grid layout with the given number of columns and
+// default gutters of 30 pixels.
+func NewGrid(cols int) *Grid {
+	return &Grid{
+		Cols:   cols,
+		ColGap: 30,
+		RowGap: 30,
+		HAlign: Left,
+		VAlign: Top,
+		cells:  make([]*Cell, 0),
+	}
+}
+
+// Grid places shapes in rows and columns. Each column is as wide as
+// its widest cell and each row as high as its highest cell. Shapes
+// are aligned within their cells, which may span multiple rows and
+// columns.
+type Grid struct {
+	// X, Y is the top left corner of the grid
+	X, Y int
+	Cols int
+	// Gutters between columns and rows
+	ColGap, RowGap int
+	// Default alignment of shapes within cells, Left, Center or
+	// Right and Top, Center or Bottom.
+	HAlign, VAlign Alignment
+
+	cells []*Cell
+}
+
+// Cell is the area of a grid occupied by one shape.
+type Cell struct {
+	Shape
+	Row, Col         int
+	RowSpan, ColSpan int
+	// Alignment within the cell overriding the grid default if set
+	HAlign, VAlign *Alignment
+
+	grid *Grid // nil if not in a grid
+}
+
+func (c *Cell) String() string {
+	return fmt.Sprintf("cell %v,%v of %v", c.Row, c.Col, c.Shape)
+}
+
+// Span sets the number of rows and columns the cell covers. Columns
+// are limited to those left of the grid.
+func (c *Cell) Span(rows, cols int) *Cell {
+	if c.grid != nil {
+		cols = minInt(cols, c.grid.cols()-c.Col)
+	}
+	c.RowSpan, c.ColSpan = rows, cols
+	return c
+}
+
+// Align sets the horizontal and vertical alignment of the shape
+// within the cell.
+func (c *Cell) Align(h, v Alignment) *Cell {
+	c.HAlign, c.VAlign = &h, &v
+	return c
+}
+
+// Add puts the shapes in the next free cells, row by row, and
+// returns the cell of the last one, i.e. Span and Align only apply to
+// the last shape. Without shapes the returned cell is not in the grid.
+func (g *Grid) Add(s ...Shape) *Cell {
+	c := &Cell{}
+	for _, s := range s {
+		row, col := g.next()
+		c = g.At(s, row, col)
+	}
+	return c
+}
+
+// At puts s in the cell at row, col counting from zero. Columns
+// beyond Cols are moved to the last column.
+func (g *Grid) At(s Shape, row, col int) *Cell {
+	col = minInt(col, g.cols()-1)
+	c := &Cell{Shape: s, Row: row, Col: col, RowSpan: 1, ColSpan: 1, grid: g}
+	g.cells = append(g.cells, c)
+	return c
+}
+
+// Shapes returns the shapes of all cells in the order added.
+func (g *Grid) Shapes() []Shape {
+	res := make([]Shape, len(g.cells))
+	for i, c := range g.cells {
+		res[i] = c.Shape
+	}
+	return res
+}
+
+// next returns the first cell not covered by any other.
+func (g *Grid) next() (row, col int) {
+	taken := g.taken()
+	for i := 0; ; i++ {
+		row, col = i/g.cols(), i%g.cols()
+		if !taken[[2]int{row, col}] {
+			return
+		}
+	}
+}
+
+// taken returns all row, col pairs covered by cells.
+func (g *Grid) taken() map[[2]int]bool {
+	taken := make(map[[2]int]bool)
+	for _, c := range g.cells {
+		for r := c.Row; r < c.Row+c.rowSpan(); r++ {
+			for k := c.Col; k < c.Col+c.colSpan(); k++ {
+				taken[[2]int{r, k}] = true
+			}
+		}
+	}
+	return taken
+}
+
+func (g *Grid) cols() int {
+	if g.Cols < 1 {
+		return 1
+	}
+	return g.Cols
+}
+
+// Place moves all shapes into their cells.
+func (g *Grid) Place() {
+	var (
+		widths  = g.sizes(false)
+		heights = g.sizes(true)
+		xs      = offsets(g.X, g.ColGap, widths)
+		ys      = offsets(g.Y, g.RowGap, heights)
+	)
+	for _, c := range g.cells {
+		w := span(widths, c.Col, c.colSpan(), g.ColGap)
+		h := span(heights, c.Row, c.rowSpan(), g.RowGap)
+		x := xs[c.Col] + within(g.align(c.HAlign, g.HAlign), w-c.Width())
+		y := ys[c.Row] + within(g.align(c.VAlign, g.VAlign), h-c.Height())
+		MoveTo(c.Shape, x, y)
+	}
+}
+
+func (g *Grid) align(cell *Alignment, def Alignment) Alignment {
+	if cell != nil {
+		return *cell
+	}
+	return def
+}
+
+// within returns the offset of a shape aligned within free space.
+func within(a Alignment, free int) int {
+	switch a {
+	case Center:
+		return free / 2
+	case Right, Bottom:
+		return free
+	}
+	return 0
+}
+
+// sizes returns the width of each column, or the height of each row
+// if vertical. Spanning cells grow the sizes they span evenly if they
+// do not fit.
+func (g *Grid) sizes(vertical bool) []int {
+	var (
+		sizes = make([]int, 0)
+		gap   = g.ColGap
+	)
+	if vertical {
+		gap = g.RowGap
+	}
+	grow := func(n int) {
+		for len(sizes) < n {
+			sizes = append(sizes, 0)
+		}
+	}
+	measure := func(c *Cell) (first, n, size int) {
+		if vertical {
+			return c.Row, c.rowSpan(), c.Height()
+		}
+		return c.Col, c.colSpan(), c.Width()
+	}
+	// single cells first so spanning cells only add what is missing
+	for _, c := range g.cells {
+		first, n, size := measure(c)
+		grow(first + n)
+		if n == 1 {
+			sizes[first] = maxInt(sizes[first], size)
+		}
+	}
+	for _, c := range g.cells {
+		first, n, size := measure(c)
+		missing := size - span(sizes, first, n, gap)
+		if n == 1 || missing <= 0 {
+			continue
+		}
+		for i := first; i < first+n; i++ {
+			sizes[i] += missing / n
+		}
+		sizes[first+n-1] += missing % n
+	}
+	return sizes
+}
+
+// offsets returns the start of each column or row.
+func offsets(start, gap int, sizes []int) []int {
+	res := make([]int, len(sizes))
+	pos := start
+	for i, size := range sizes {
+		res[i] = pos
+		pos += size + gap
+	}
+	return res
+}
+
+// span returns the total size of n sizes from first including gaps
+// between them.
+func span(sizes []int, first, n, gap int) int {
+	total := gap * (n - 1)
+	for _, size := range sizes[first : first+n] {
+		total += size
+	}
+	return total
+}
+
+func (c *Cell) rowSpan() int { return maxInt(c.RowSpan, 1) }
+func (c *Cell) colSpan() int { return maxInt(c.ColSpan, 1) }
@@ -0,0 +1,77 @@
+package shape
+
+import (
+	"testing"
+
+	"github.com/gregoryv/asserter"
+)
+
+func TestGrid_Place(t *testing.T) {
+	var (
+		a    = NewRect("a")
+		b    = NewRect("b is much wider")
+		c    = NewRect("c")
+		d    = NewCircle(20)
+		e    = NewRect("e spans two columns and is widest of all")
+		svg  = newSvg(500, 300, a, b, c, d, e)
+		grid = NewGrid(2)
+	)
+	grid.X, grid.Y = 10, 10
+	grid.ColGap, grid.RowGap = 20, 10
+	grid.Add(a, b, c)
+	grid.Add(d).Align(Right, Bottom)
+	grid.Add(e).Span(1, 2)
+	grid.Place()
+	writeSvgTo(t, "testdata/grid.svg", svg)
+
+	assert := asserter.New(t)
+	ax, ay := a.Position()
+	assert(ax == 10 && ay == 10).Errorf("a at %v,%v", ax, ay)
+	// second column starts after the widest of the first
+	bx, by := b.Position()
+	assert(by == ay).Errorf("b not on first row: %v", by)
+	assert(bx >= 10+maxInt(a.Width(), c.Width())+20).Errorf("b at x %v", bx)
+	// the spanning e makes the columns exactly as wide as itself
+	right := 10 + e.Width()
+	dx, dy := d.Position()
+	assert(dx+d.Width() == right).Errorf("d not right aligned: %v", dx)
+	_, cy := c.Position()
+	assert(cy == ay+a.Height()+10).Errorf("c at y %v", cy)
+	assert(dy+d.Height() == cy+maxInt(c.Height(), d.Height())).Errorf("d not bottom aligned: %v", dy)
+	ex, _ := e.Position()
+	assert(ex == 10).Errorf("e at x %v", ex)
+}
+
+func TestGrid_Add(t *testing.T) {
+	grid := NewGrid(3)
+	grid.Add(NewRect("a")).Span(2, 2)
+	c := grid.Add(NewRect("b"))
+	assert := asserter.New(t)
+	assert(c.Row == 0 && c.Col == 2).Errorf("b in %v", c)
+	c = grid.Add(NewRect("c"))
+	assert(c.Row == 1 && c.Col == 2).Errorf("c skipped span: %v", c)
+	c = grid.Add(NewRect("d"))
+	assert(c.Row == 2 && c.Col == 0).Errorf("d in %v", c)
+	assert(len(grid.Shapes()) == 4).Error(grid.Shapes())
+
+	grid.Add().Span(2, 2).Align(Center, Center) // no shapes, no panic
+	assert(len(grid.Shapes()) == 4).Error("empty add changed grid")
+	c = grid.Add(NewRect("e"), NewRect("f")).Span(1, 3)
+	assert(c.Col == 2 && c.ColSpan == 1).Errorf("f spans beyond columns: %v, %v", c, c.ColSpan)
+	c = grid.At(NewRect("g"), 4, 5)
+	assert(c.Col == 2).Errorf("g beyond columns: %v", c)
+}
+
+func TestGrid_Place_center(t *testing.T) {
+	var (
+		a    = NewRect("a")
+		b    = NewRect("b is much wider")
+		grid = NewGrid(1)
+	)
+	grid.HAlign = Center
+	grid.Add(a, b)
+	grid.Place()
+	assert := asserter.New(t)
+	ax, _ := a.Position()
+	assert(ax == (b.Width()-a.Width())/2).Errorf("a not centered: %v", ax)
+}
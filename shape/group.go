@@ -0,0 +1,142 @@
+package shape
+
+import (
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/gregoryv/go-design/xy"
+)
+
+// NewGroup returns a group of shapes positioned relative to the
+// group.
+func NewGroup(children ...Shape) *Group {
+	return &Group{
+		Children:  children,
+		Transform: xy.Identity(),
+		class:     "group",
+	}
+}
+
+// Group transforms its children with an affine matrix. The position
+// and size of a group is the bounding box of its transformed
+// children.
+type Group struct {
+	Hyperlink
+
+	Children  []Shape
+	Transform xy.Matrix
+	class     string
+}
+
+func (g *Group) String() string {
+	return fmt.Sprintf("Group of %v shapes", len(g.Children))
+}
+
+// Translate moves the group by tx, ty.
+func (g *Group) Translate(tx, ty float64) {
+	g.Transform = g.Transform.Then(xy.Translate(tx, ty))
+}
+
+// Scale scales the group around its center.
+func (g *Group) Scale(sx, sy float64) {
+	g.around(xy.Scale(sx, sy))
+}
+
+// Rotate rotates the group clockwise by degrees around its center.
+func (g *Group) Rotate(degrees float64) {
+	g.around(xy.Rotate(degrees))
+}
+
+// Skew skews the group by degrees along each axis around its center.
+func (g *Group) Skew(x, y float64) {
+	g.around(xy.SkewX(x).Then(xy.SkewY(y)))
+}
+
+// around applies m with the center of the group as origin.
+func (g *Group) around(m xy.Matrix) {
+	c := g.bounds().Center()
+	g.Transform = g.Transform.
+		Then(xy.Translate(-c.X, -c.Y)).
+		Then(m).
+		Then(xy.Translate(c.X, c.Y))
+}
+
+// local returns the bounding box of the untransformed children.
+func (g *Group) local() xy.Box {
+	if len(g.Children) == 0 {
+		return xy.Box{}
+	}
+	r := Bounds(g.Children[0])
+	for _, s := range g.Children[1:] {
+		r = r.Union(Bounds(s))
+	}
+	return r
+}
+
+// bounds returns the bounding box of the transformed children.
+func (g *Group) bounds() xy.Box {
+	return g.Transform.Bounds(g.local())
+}
+
+func (g *Group) Position() (int, int) {
+	b := g.bounds()
+	return int(math.Floor(b.TopLeft.X)), int(math.Floor(b.TopLeft.Y))
+}
+
+// SetX moves the group so the left of its bounding box is at x.
+func (g *Group) SetX(x int) {
+	left, _ := g.Position()
+	g.Translate(float64(x-left), 0)
+}
+
+// SetY moves the group so the top of its bounding box is at y.
+func (g *Group) SetY(y int) {
+	_, top := g.Position()
+	g.Translate(0, float64(y-top))
+}
+
+func (g *Group) Width() int {
+	x, _ := g.Position()
+	return int(math.Ceil(g.bounds().BottomRight.X)) - x
+}
+
+func (g *Group) Height() int {
+	_, y := g.Position()
+	return int(math.Ceil(g.bounds().BottomRight.Y)) - y
+}
+
+func (g *Group) Direction() Direction { return LR }
+func (g *Group) SetClass(c string)    { g.class = c }
+
+func (g *Group) WriteSvg(out io.Writer) error {
+	w, err := newTagPrinter(out)
+	w.open("g", attr{"class", g.class}, attr{"transform", g.Transform})
+	for _, s := range g.Children {
+		writeShape(w, s)
+	}
+	w.close("g")
+	return *err
+}
+
+// Edge returns the position where a line from start to the center of
+// the group crosses its outline. The outline of a single child
+// with an edge is transformed with it, e.g. a rotated circle. Groups
+// of many children use their transformed bounding box.
+func (g *Group) Edge(start xy.Position) xy.Position {
+	inv, ok := g.Transform.Invert()
+	if len(g.Children) == 1 && ok {
+		if e, ok := g.Children[0].(Edge); ok {
+			// transform the line, not the child
+			p := e.Edge(inv.Apply(start.Point()).Position())
+			return g.Transform.Apply(p.Point()).Position()
+		}
+	}
+	corners := g.Transform.Corners(g.local())
+	outline := make(xy.Polygon, len(corners))
+	for i, c := range corners {
+		outline[i] = c.Position()
+	}
+	inside := g.Transform.Apply(g.local().Center()).Position()
+	return polygonEdge(start, inside, outline)
+}
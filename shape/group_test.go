@@ -0,0 +1,68 @@
+package shape
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/gregoryv/asserter"
+	"github.com/gregoryv/go-design/xy"
+)
+
+func TestGroup(t *testing.T) {
+	testShape(t, NewGroup(NewRect("a")))
+}
+
+func TestGroup_transforms(t *testing.T) {
+	var (
+		rect   = NewRect("rotated")
+		circle = NewCircle(20)
+		tilted = NewGroup(rect)
+		oval   = NewGroup(circle)
+		from   = NewRect("from")
+		svg    = newSvg(360, 220, from)
+	)
+	w, h := rect.Width(), rect.Height()
+	tilted.Rotate(90)
+	tilted.SetX(200)
+	tilted.SetY(20)
+	oval.Scale(2, 1)
+	oval.SetX(40)
+	oval.SetY(140)
+	from.SetX(10)
+	from.SetY(10)
+	svg.Append(tilted, oval,
+		NewArrowBetween(from, tilted),
+		NewArrowBetween(from, oval),
+	)
+	writeSvgTo(t, "testdata/group_transforms.svg", svg)
+
+	assert := asserter.New(t)
+	x, y := tilted.Position()
+	assert(x == 200 && y == 20).Errorf("moved to %v,%v", x, y)
+	assert(intAbs(tilted.Width()-h) <= 1).Errorf("width %v", tilted.Width())
+	assert(intAbs(tilted.Height()-w) <= 1).Errorf("height %v", tilted.Height())
+
+	// the edge of a scaled circle is an ellipse
+	p := oval.Edge(xy.Position{X: 200, Y: 160})
+	c := center(oval)
+	rx, ry := float64(oval.Width())/2, float64(oval.Height())/2
+	dx, dy := float64(p.X-c.X)/rx, float64(p.Y-c.Y)/ry
+	v := math.Hypot(dx, dy)
+	assert(math.Abs(v-1) < 0.1).Errorf("%v not on ellipse %v", p, v)
+
+	buf := &bytes.Buffer{}
+	tilted.WriteSvg(buf)
+	assert().Contains(buf.String(), `transform="matrix(0 1 -1 0`)
+}
+
+func TestGroup_Edge_manyChildren(t *testing.T) {
+	a, b := NewRect("a"), NewRect("b")
+	b.SetX(100)
+	g := NewGroup(a, b)
+	g.Skew(10, 0)
+	p := g.Edge(xy.Position{X: 60, Y: -100})
+	_, top := g.Position()
+	assert := asserter.New(t)
+	assert(p.Y >= top && p.Y < top+g.Height()/2).Errorf("%v not on top", p)
+}
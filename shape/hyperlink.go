@@ -0,0 +1,70 @@
+package shape
+
+// Hyperlink makes a shape interactive. Shapes with a URL are written
+// wrapped in a link opening it when clicked and the tooltip is shown
+// by browsers on hover. Both are optional.
+type Hyperlink struct {
+	URL     string
+	Tooltip string
+}
+
+// SetURL sets the url opened when the shape is clicked.
+func (h *Hyperlink) SetURL(url string) { h.URL = url }
+
+// SetTooltip sets the text shown when hovering the shape.
+func (h *Hyperlink) SetTooltip(txt string) { h.Tooltip = txt }
+
+func (h *Hyperlink) hyperlink() *Hyperlink { return h }
+
+// tooltipOr returns the tooltip or def if not set.
+func (h *Hyperlink) tooltipOr(def string) string {
+	if h.Tooltip != "" {
+		return h.Tooltip
+	}
+	return def
+}
+
+// HasHyperlink shapes can link to other documents and show a
+// tooltip, all shapes in this package do.
+type HasHyperlink interface {
+	SetURL(string)
+	SetTooltip(string)
+}
+
+// hyperlinked is implemented by shapes embedding Hyperlink.
+type hyperlinked interface {
+	hyperlink() *Hyperlink
+}
+
+// ownTooltip is implemented by shapes writing their tooltip
+// themselves, e.g. as title of their frame.
+type ownTooltip interface {
+	ownTooltip()
+}
+
+func (r *Record) ownTooltip() {}
+func (r *State) ownTooltip()  {}
+
+// writeLinked writes s wrapped in an a element if it has a url and
+// with a title element if it has a tooltip not written by s.
+func writeLinked(w *tagPrinter, s Shape) {
+	var h Hyperlink
+	if s, ok := s.(hyperlinked); ok {
+		h = *s.hyperlink()
+	}
+	if _, ok := s.(ownTooltip); ok {
+		h.Tooltip = ""
+	}
+	switch {
+	case h.URL != "":
+		w.open("a", attr{"xlink:href", h.URL})
+		defer w.close("a")
+	case h.Tooltip != "":
+		w.open("g")
+		defer w.close("g")
+	}
+	if h.Tooltip != "" {
+		w.text("title", h.Tooltip)
+	}
+	s.WriteSvg(w)
+}
@@ -0,0 +1,61 @@
+package shape
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/gregoryv/asserter"
+)
+
+func TestHyperlink(t *testing.T) {
+	rect := NewRect("linked")
+	rect.SetURL("https://example.com/?a=1&b=2")
+	rect.SetTooltip("Go to example")
+	label := NewLabel("tip only")
+	label.SetTooltip("a tooltip")
+	svg := &Svg{Content: []Shape{rect, label, NewCircle(4)}}
+	var buf bytes.Buffer
+	err := svg.WriteSvg(&buf)
+	assert := asserter.New(t)
+	assert(err == nil).Fatal(err)
+	got := buf.String()
+	assert().Contains(got, `<a xlink:href="https://example.com/?a=1&amp;b=2">`)
+	assert().Contains(got, `<title>Go to example</title>`)
+	assert().Contains(got, `<title>a tooltip</title>`)
+	assert(strings.Count(got, "<a ") == 1).Error("only linked shapes are wrapped\n", got)
+	if err := xml.Unmarshal(buf.Bytes(), new(interface{})); err != nil {
+		t.Error(err, "\n", got)
+	}
+}
+
+func TestHyperlink_tooltipOverridesTitle(t *testing.T) {
+	for _, s := range []Shape{NewRecord("Car"), NewState("idle")} {
+		s.(HasHyperlink).SetTooltip("custom")
+		s.(HasHyperlink).SetURL("https://example.com")
+		var buf bytes.Buffer
+		(&Svg{Content: []Shape{s}}).WriteSvg(&buf)
+		got := buf.String()
+		if n := strings.Count(got, "<title>custom</title>"); n != 1 {
+			t.Errorf("%T tooltip written %v times\n%s", s, n, got)
+		}
+		if strings.Contains(got, "<title>Car</title>") {
+			t.Errorf("%T title written as tooltip\n%s", s, got)
+		}
+	}
+}
+
+func TestHyperlink_parsed(t *testing.T) {
+	r := NewRecord("Car")
+	r.SetURL("https://pkg.go.dev/example.com/car#Car")
+	r.SetTooltip("a car")
+	var buf bytes.Buffer
+	(&Svg{Content: []Shape{r}, Meta: true}).WriteSvg(&buf)
+	svg, err := ParseSvg(&buf)
+	assert := asserter.New(t)
+	assert(err == nil).Fatal(err)
+	got := svg.Content[0].(*Record)
+	assert().Equals(got.URL, r.URL)
+	assert().Equals(got.Tooltip, r.Tooltip)
+}
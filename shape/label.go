@@ -2,7 +2,6 @@ package shape
 
 import (
 	"fmt"
-	"html/template"
 	"io"
 
 	"github.com/gregoryv/go-design/xy"
@@ -10,7 +9,7 @@ import (
 
 func NewLabel(text string) *Label {
 	return &Label{
-		Text:  template.HTMLEscapeString(text),
+		Text:  text,
 		Font:  DefaultFont,
 		Pad:   DefaultPad,
 		class: "label",
@@ -18,6 +17,8 @@ func NewLabel(text string) *Label {
 }
 
 type Label struct {
+	Hyperlink
+
 	Pos   xy.Position
 	Text  string
 	Font  Font
@@ -43,13 +44,16 @@ func (l *Label) Height() int          { return l.Font.LineHeight }
 func (l *Label) Direction() Direction { return LR }
 func (l *Label) SetClass(c string)    { l.class = c }
 
-func (l *Label) WriteSvg(w io.Writer) error {
+func (l *Label) WriteSvg(out io.Writer) error {
+	w, err := newTagPrinter(out)
 	x, y := l.Position()
 	y += l.Font.LineHeight
-	_, err := fmt.Fprintf(w,
-		`<text class="%s" font-size="%vpx" x="%v" y="%v">%s</text>`,
-		l.class, l.Font.Height, x, y, l.Text)
-	return err
+	w.text("text", l.Text,
+		attr{"class", l.class},
+		attr{"font-size", fmt.Sprintf("%vpx", l.Font.Height)},
+		attr{"x", x}, attr{"y", y},
+	)
+	return *err
 }
 
 func (l *Label) Edge(start xy.Position) xy.Position {
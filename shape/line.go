@@ -9,13 +9,15 @@ import (
 
 func NewLine(x1, y1 int, x2, y2 int) *Line {
 	return &Line{
-		Start: xy.Position{x1, y1},
-		End:   xy.Position{x2, y2},
+		Start: xy.Position{X: x1, Y: y1},
+		End:   xy.Position{X: x2, Y: y2},
 		class: "line",
 	}
 }
 
 type Line struct {
+	Hyperlink
+
 	Start xy.Position
 	End   xy.Position
 
@@ -26,14 +28,14 @@ func (line *Line) String() string {
 	return fmt.Sprintf("Line from %v to %v", line.Start, line.End)
 }
 
-func (line *Line) WriteSvg(w io.Writer) error {
-	_, err := fmt.Fprintf(w,
-		`<line class="%s" x1="%v" y1="%v" x2="%v" y2="%v"/>`,
-		line.class,
-		line.Start.X, line.Start.Y,
-		line.End.X, line.End.Y,
+func (line *Line) WriteSvg(out io.Writer) error {
+	w, err := newTagPrinter(out)
+	w.empty("line",
+		attr{"class", line.class},
+		attr{"x1", line.Start.X}, attr{"y1", line.Start.Y},
+		attr{"x2", line.End.X}, attr{"y2", line.End.Y},
 	)
-	return err
+	return *err
 }
 
 func (line *Line) Position() (int, int) {
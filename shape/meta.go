@@ -0,0 +1,181 @@
+package shape
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gregoryv/go-design/xy"
+)
+
+// writeShape writes s, wrapped in a group of data-* attributes
+// describing it if the printer embeds metadata. See ParseSvg.
+func writeShape(w *tagPrinter, s Shape) {
+	if w.ids == nil {
+		writeLinked(w, s)
+		return
+	}
+	attrs := w.describe(s)
+	if attrs == nil {
+		writeLinked(w, s)
+		return
+	}
+	w.open("g", attrs...)
+	writeLinked(w, s)
+	w.close("g")
+}
+
+// id returns the identity of s within the written svg, shapes that
+// cannot be identified get 0.
+func (w *tagPrinter) id(s Shape) int {
+	if s == nil || !reflect.TypeOf(s).Comparable() {
+		return 0
+	}
+	if id, found := w.ids[s]; found {
+		return id
+	}
+	id := len(w.ids) + 1
+	w.ids[s] = id
+	return id
+}
+
+// record is implemented by Record and types embedding it.
+type record interface {
+	record() *Record
+}
+
+func (r *Record) record() *Record { return r }
+
+// describe returns the data-* attributes needed to recreate s, nil
+// for unknown shapes.
+func (w *tagPrinter) describe(s Shape) []attr {
+	var (
+		kind  string
+		class string
+		more  []attr
+	)
+	switch s := s.(type) {
+	case *Rect:
+		kind, class = "rect", s.class
+		more = titled(s.Title, s.width, s.height)
+	case *State:
+		kind, class = "state", s.class
+		more = titled(s.Title, s.width, s.height)
+	case *Component:
+		kind, class = "component", s.class
+		more = titled(s.Title, s.width, s.height)
+	case *Label:
+		kind, class = "label", s.class
+		more = []attr{{"data-text", s.Text}}
+	case *Note:
+		kind, class = "note", s.class
+		more = []attr{{"data-text", s.Text}}
+	case *Circle:
+		kind, class = "circle", s.class
+		more = []attr{{"data-radius", s.Radius}}
+	case *Dot:
+		kind, class = "dot", s.class
+		more = []attr{{"data-radius", s.Radius}}
+	case *ExitDot:
+		kind, class = "exitdot", s.class
+		more = []attr{{"data-radius", s.Radius}}
+	case *Diamond:
+		kind, class = "diamond", s.class
+		more = []attr{{"data-size", coords(s.width, s.height)}}
+	case *Line:
+		kind, class = "line", s.class
+		more = []attr{
+			{"data-start", coords(s.Start.XY())},
+			{"data-end", coords(s.End.XY())},
+		}
+	case *Arrow:
+		kind, class = "arrow", s.class
+		more = w.describeArrow(s)
+	case *Container:
+		kind, class = "container", s.class
+		more = []attr{{"data-title", s.Title}, {"data-tab", s.Tab}}
+	case *Group:
+		kind, class = "group", s.class
+		more = []attr{{"data-transform", s.Transform}}
+	case record:
+		r := s.record()
+		kind, class = "record", r.class
+		more = []attr{
+			{"data-title", r.Title},
+			{"data-fields", strings.Join(r.Fields, "\n")},
+			{"data-methods", strings.Join(r.Methods, "\n")},
+		}
+		more = append(more, titled(r.Title, r.width, r.height)[1:]...)
+	default:
+		return nil
+	}
+	x, y := s.Position()
+	attrs := []attr{
+		{"data-shape", kind},
+		{"data-id", w.id(s)},
+		{"data-pos", coords(x, y)},
+		{"data-style", class},
+	}
+	attrs = append(attrs, more...)
+	if h, ok := s.(hyperlinked); ok {
+		h := h.hyperlink()
+		if h.URL != "" {
+			attrs = append(attrs, attr{"data-url", h.URL})
+		}
+		if h.Tooltip != "" {
+			attrs = append(attrs, attr{"data-tooltip", h.Tooltip})
+		}
+	}
+	return attrs
+}
+
+func (w *tagPrinter) describeArrow(a *Arrow) []attr {
+	points := make([]string, len(a.Waypoints))
+	for i, p := range a.Waypoints {
+		points[i] = coords(p.XY())
+	}
+	labels := make([]string, len(a.Labels))
+	for i, l := range a.Labels {
+		labels[i] = fmt.Sprintf("%d %v %s %s", l.At, l.Below, l.class, l.Text)
+	}
+	attrs := []attr{
+		{"data-start", coords(a.Start.XY())},
+		{"data-end", coords(a.End.XY())},
+		{"data-waypoints", strings.Join(points, " ")},
+		{"data-curved", a.Curved},
+		{"data-labels", strings.Join(labels, "\n")},
+	}
+	if _, ok := a.Tail.(*Diamond); ok {
+		attrs = append(attrs, attr{"data-tail", "diamond"})
+	}
+	if a.from != nil && a.to != nil {
+		attrs = append(attrs,
+			attr{"data-from", w.id(a.from)},
+			attr{"data-to", w.id(a.to)},
+		)
+	}
+	return attrs
+}
+
+// titled returns attributes of box shapes with a title and minimum
+// size.
+func titled(title string, width, height int) []attr {
+	return []attr{{"data-title", title}, {"data-min", coords(width, height)}}
+}
+
+func coords(x, y int) string { return fmt.Sprintf("%v,%v", x, y) }
+
+// parseCoords returns the two integers of coordinates written as "x,y".
+func parseCoords(s string) (x, y int, err error) {
+	_, err = fmt.Sscanf(s, "%d,%d", &x, &y)
+	return
+}
+
+// position returns the pair as a position transformed by m.
+func position(s string, m xy.Matrix) (xy.Position, error) {
+	x, y, err := parseCoords(s)
+	if err != nil {
+		return xy.Position{}, err
+	}
+	return m.Apply(xy.Position{X: x, Y: y}.Point()).Position(), nil
+}
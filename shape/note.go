@@ -18,6 +18,8 @@ func NewNote(text string) *Note {
 }
 
 type Note struct {
+	Hyperlink
+
 	Pos  xy.Position
 	Text string
 
@@ -53,11 +55,14 @@ func (n *Note) Height() int {
 }
 func (n *Note) SetClass(c string) { n.class = c }
 
+// noteFlap is the size of the folded corner
+const noteFlap = 10
+
 func (n *Note) WriteSvg(out io.Writer) error {
 	x, y := n.Pos.XY()
 	w := n.Width()
 	h := n.Height()
-	flap := 10
+	flap := noteFlap
 	t, err := newTagPrinter(out)
 	/*
 	   x,y
@@ -68,15 +73,34 @@ func (n *Note) WriteSvg(out io.Writer) error {
 	    +-----------------+
 	   y+h               x+w
 	*/
-	t.printf(`<path class="%s-box" d="M%v,%v `, n.class, x, y)
-	t.printf(`v %v h %v v %v l %v,%v L %v,%v M%v,%v h %v v %v"/>`,
-		h, w, -(h - flap), -flap, -flap, x, y, x+w, y+flap, -flap, -flap)
-	t.print("\n")
+	t.empty("path",
+		attr{"class", n.class + "-box"},
+		attr{"d", fmt.Sprintf(
+			"M%v,%v v %v h %v v %v l %v,%v L %v,%v M%v,%v h %v v %v",
+			x, y, h, w, -(h - flap), -flap, -flap, x, y, x+w, y+flap, -flap, -flap,
+		)},
+	)
 	x += n.Pad.Left
 	for i, line := range strings.Split(n.Text, "\n") {
-		t.printf(`<text class="note" font-size="%vpx" x="%v" y="%v">%s</text>`,
-			n.Font.Height, x, y+(n.Font.LineHeight*(i+1)), line)
-		t.print("\n")
+		t.text("text", line,
+			attr{"class", "note"},
+			attr{"font-size", fmt.Sprintf("%vpx", n.Font.Height)},
+			attr{"x", x}, attr{"y", y + (n.Font.LineHeight * (i + 1))},
+		)
 	}
 	return *err
 }
+
+// Edge returns the position where a line from start to the center of
+// the note crosses it, following the folded corner.
+func (n *Note) Edge(start xy.Position) xy.Position {
+	x, y := n.Pos.XY()
+	w, h := n.Width(), n.Height()
+	return polygonEdge(start, center(n), xy.Polygon{
+		{X: x, Y: y},
+		{X: x + w - noteFlap, Y: y},
+		{X: x + w, Y: y + noteFlap},
+		{X: x + w, Y: y + h},
+		{X: x, Y: y + h},
+	})
+}
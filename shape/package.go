@@ -38,12 +38,53 @@ func intAbs(v int) int {
 	return int(math.Abs(float64(v)))
 }
 
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
 type Edge interface {
 	// Edge returns the intersecting position to a shape from start
 	// position.
 	Edge(start xy.Position) xy.Position
 }
 
+// Resizable shapes can be made larger than needed for their
+// content.
+type Resizable interface {
+	// SetWidth sets the minimum width.
+	SetWidth(int)
+	// SetHeight sets the minimum height.
+	SetHeight(int)
+}
+
+// TextAlign positions text within a box larger than the text needs.
+// H is Left, Center or Right and V is Top, Center or Bottom, the zero
+// value aligns text to the top left.
+type TextAlign struct {
+	H, V Alignment
+}
+
+// offset returns how far text is moved within the free width and
+// height.
+func (a TextAlign) offset(w, h int) (int, int) {
+	return within(a.H, w), within(a.V, h)
+}
+
+// HasTextAlign shapes can align their text within them.
+type HasTextAlign interface {
+	SetTextAlign(h, v Alignment)
+}
+
 type HasFont interface {
 	SetFont(Font)
 }
@@ -0,0 +1,252 @@
+package shape
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/gregoryv/go-design/xy"
+)
+
+// ParseSvg reads an svg written with Svg.Meta set and returns the
+// shapes described by it. Shapes moved in an editor, i.e. given a
+// transform, are placed where they were moved and arrows between
+// shapes are reconnected. Fonts and padding are not kept, use e.g.
+// Diagram.Place to style the shapes again.
+func ParseSvg(r io.Reader) (*Svg, error) {
+	p := &parser{
+		svg:  &Svg{Content: make([]Shape, 0)},
+		ids:  make(map[string]Shape),
+		ends: make(map[*Arrow][2]string),
+	}
+	if err := p.parse(xml.NewDecoder(r)); err != nil {
+		return nil, err
+	}
+	for arrow, ends := range p.ends {
+		from, to := p.ids[ends[0]], p.ids[ends[1]]
+		if from == nil || to == nil {
+			continue
+		}
+		arrow.from, arrow.to = from, to
+		arrow.Reconnect()
+	}
+	return p.svg, nil
+}
+
+type parser struct {
+	svg *Svg
+	// shapes by data-id
+	ids map[string]Shape
+	// ids of the shapes each arrow is drawn between
+	ends map[*Arrow][2]string
+}
+
+// frame is an open g element.
+type frame struct {
+	parent Shape     // container or group receiving shapes, nil for svg
+	m      xy.Matrix // transforms added by an editor
+}
+
+func (p *parser) parse(dec *xml.Decoder) error {
+	stack := []frame{{m: xy.Identity()}}
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			a := attrs(tok.Attr)
+			switch tok.Name.Local {
+			case "svg":
+				p.svg.Width, _ = strconv.Atoi(a["width"])
+				p.svg.Height, _ = strconv.Atoi(a["height"])
+			case "g":
+				top := stack[len(stack)-1]
+				if a["data-shape"] == "" {
+					stack = append(stack, top)
+					continue
+				}
+				m, err := xy.ParseTransform(a["transform"])
+				if err != nil {
+					return err
+				}
+				if _, ok := top.parent.(*Group); !ok {
+					m = m.Then(top.m)
+				}
+				s, err := p.shape(a, m)
+				if err != nil {
+					return err
+				}
+				if h, ok := s.(HasHyperlink); ok {
+					h.SetURL(a["data-url"])
+					h.SetTooltip(a["data-tooltip"])
+				}
+				p.add(top.parent, s)
+				stack = append(stack, frame{parent: s, m: m})
+			}
+		case xml.EndElement:
+			if tok.Name.Local == "g" && len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+}
+
+// add appends s to the parent or the svg if parent is not a
+// container or group.
+func (p *parser) add(parent, s Shape) {
+	switch parent := parent.(type) {
+	case *Container:
+		parent.Children = append(parent.Children, s)
+	case *Group:
+		parent.Children = append(parent.Children, s)
+	default:
+		p.svg.Content = append(p.svg.Content, s)
+	}
+}
+
+// shape returns the shape described by the data-* attributes, moved
+// by m.
+func (p *parser) shape(a map[string]string, m xy.Matrix) (Shape, error) {
+	var s Shape
+	switch kind := a["data-shape"]; kind {
+	case "rect":
+		r := NewRect(a["data-title"])
+		r.width, r.height, _ = parseCoords(a["data-min"])
+		s = r
+	case "state":
+		r := NewState(a["data-title"])
+		r.width, r.height, _ = parseCoords(a["data-min"])
+		s = r
+	case "component":
+		r := NewComponent(a["data-title"])
+		r.width, r.height, _ = parseCoords(a["data-min"])
+		s = r
+	case "record":
+		r := NewRecord(a["data-title"])
+		r.Fields = lines(a["data-fields"])
+		r.Methods = lines(a["data-methods"])
+		r.width, r.height, _ = parseCoords(a["data-min"])
+		s = r
+	case "label":
+		s = NewLabel(a["data-text"])
+	case "note":
+		s = NewNote(a["data-text"])
+	case "circle":
+		s = NewCircle(atoi(a["data-radius"]))
+	case "dot":
+		s = NewDot(atoi(a["data-radius"]))
+	case "exitdot":
+		d := NewExitDot()
+		d.Radius = atoi(a["data-radius"])
+		s = d
+	case "diamond":
+		d := NewDiamond()
+		d.width, d.height, _ = parseCoords(a["data-size"])
+		s = d
+	case "line":
+		start, err := position(a["data-start"], m)
+		if err != nil {
+			return nil, err
+		}
+		end, err := position(a["data-end"], m)
+		if err != nil {
+			return nil, err
+		}
+		line := NewLine(start.X, start.Y, end.X, end.Y)
+		line.SetClass(a["data-style"])
+		return line, nil
+	case "arrow":
+		return p.arrow(a, m)
+	case "container":
+		c := NewContainer(a["data-title"])
+		c.Tab = a["data-tab"] == "true"
+		s = c
+	case "group":
+		g := NewGroup()
+		t, err := xy.ParseTransform(a["data-transform"])
+		if err != nil {
+			return nil, err
+		}
+		g.Transform = t.Then(m)
+		g.SetClass(a["data-style"])
+		p.ids[a["data-id"]] = g
+		return g, nil
+	default:
+		return nil, fmt.Errorf("unknown data-shape %q", kind)
+	}
+	s.SetClass(a["data-style"])
+	pos, err := position(a["data-pos"], m)
+	if err != nil {
+		return nil, err
+	}
+	MoveTo(s, pos.X, pos.Y)
+	p.ids[a["data-id"]] = s
+	return s, nil
+}
+
+func (p *parser) arrow(a map[string]string, m xy.Matrix) (*Arrow, error) {
+	start, err := position(a["data-start"], m)
+	if err != nil {
+		return nil, err
+	}
+	end, err := position(a["data-end"], m)
+	if err != nil {
+		return nil, err
+	}
+	arrow := NewArrow(start.X, start.Y, end.X, end.Y)
+	arrow.SetClass(a["data-style"])
+	arrow.Curved = a["data-curved"] == "true"
+	for _, wp := range strings.Fields(a["data-waypoints"]) {
+		pos, err := position(wp, m)
+		if err != nil {
+			return nil, err
+		}
+		arrow.Waypoints = append(arrow.Waypoints, pos)
+	}
+	if a["data-tail"] == "diamond" {
+		arrow.Tail = NewDiamond()
+	}
+	for _, l := range lines(a["data-labels"]) {
+		f := strings.SplitN(l, " ", 4)
+		if len(f) != 4 {
+			return nil, fmt.Errorf("malformed arrow label %q", l)
+		}
+		label := arrow.AddLabel(Anchor(atoi(f[0])), f[3])
+		label.Below = f[1] == "true"
+		label.SetClass(f[2])
+	}
+	if a["data-from"] != "" && a["data-to"] != "" {
+		p.ends[arrow] = [2]string{a["data-from"], a["data-to"]}
+	}
+	return arrow, nil
+}
+
+// attrs returns the attributes by local name, e.g. xlink:href is
+// href.
+func attrs(list []xml.Attr) map[string]string {
+	res := make(map[string]string, len(list))
+	for _, a := range list {
+		res[a.Name.Local] = a.Value
+	}
+	return res
+}
+
+// lines returns the lines of s, none if s is empty.
+func lines(s string) []string {
+	if s == "" {
+		return []string{}
+	}
+	return strings.Split(s, "\n")
+}
+
+func atoi(s string) int {
+	v, _ := strconv.Atoi(s)
+	return v
+}
@@ -0,0 +1,101 @@
+package shape
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gregoryv/asserter"
+)
+
+func TestParseSvg(t *testing.T) {
+	var (
+		rect   = NewRect("a & b")
+		record = NewRecord("Tree[T any]")
+		state  = NewState("waiting")
+		note   = NewNote("first\nsecond")
+		dia    = NewDecision()
+		box    = NewContainer("pkg")
+		inner  = NewComponent("inner")
+		svg    = &Svg{Width: 400, Height: 300, Meta: true}
+	)
+	record.Fields = []string{"left *Tree[T]", "right *Tree[T]"}
+	record.Methods = []string{"Less<T>(a, b T) bool"}
+	rect.SetWidth(100)
+	rect.SetX(10)
+	rect.SetY(20)
+	record.SetX(200)
+	record.SetY(20)
+	state.SetX(10)
+	state.SetY(150)
+	note.Pos.X, note.Pos.Y = 200, 150
+	dia.SetX(150)
+	dia.SetY(100)
+	box.SetX(10)
+	box.SetY(200)
+	box.Place(inner)
+	arrow := NewArrowBetween(rect, record)
+	arrow.Tail = NewDiamond()
+	arrow.AddLabel(AtMiddle, "owns").Below = true
+	svg.Append(arrow, rect, record, state, note, dia, box)
+
+	var buf bytes.Buffer
+	err := svg.WriteSvg(&buf)
+	assert := asserter.New(t)
+	assert(err == nil).Fatal(err)
+	written := buf.String()
+
+	got, err := ParseSvg(strings.NewReader(written))
+	assert(err == nil).Fatal(err)
+	assert(got.Width == 400 && got.Height == 300).Errorf("size %v,%v", got.Width, got.Height)
+	assert(len(got.Content) == len(svg.Content)).Fatalf("%v", got.Content)
+	for i, exp := range svg.Content {
+		s := got.Content[i]
+		assert(fmt.Sprintf("%T", s) == fmt.Sprintf("%T", exp)).Errorf("%T != %T", s, exp)
+		assert(pos(s) == pos(exp)).Errorf("%v at %v, expected %v", s, pos(s), pos(exp))
+		assert(s.Width() == exp.Width()).Errorf("%v width %v, expected %v", s, s.Width(), exp.Width())
+	}
+	r := got.Content[2].(*Record)
+	assert(r.Title == record.Title).Error(r.Title)
+	assert(strings.Join(r.Methods, ";") == "Less<T>(a, b T) bool").Error(r.Methods)
+	assert(len(r.Fields) == 2).Error(r.Fields)
+	assert(got.Content[4].(*Note).Text == note.Text).Error("note text")
+	c := got.Content[6].(*Container)
+	assert(len(c.Children) == 1).Fatal(c.Children)
+	assert(pos(c.Children[0]) == pos(inner)).Errorf("inner at %v", pos(c.Children[0]))
+	a := got.Content[0].(*Arrow)
+	assert(a.Tail != nil).Error("lost tail")
+	assert(len(a.Labels) == 1 && a.Labels[0].Text == "owns" && a.Labels[0].Below).Error(a.Labels)
+	from, to := a.Between()
+	assert(from == got.Content[1] && to == got.Content[2]).Error("arrow not between shapes")
+
+	// again, as if the record was moved in an editor
+	moved := strings.Replace(written,
+		`<g data-shape="record"`, `<g transform="translate(40,100)" data-shape="record"`, 1,
+	)
+	got, err = ParseSvg(strings.NewReader(moved))
+	assert(err == nil).Fatal(err)
+	x, y := got.Content[2].Position()
+	assert(x == 240 && y == 120).Errorf("moved record at %v,%v", x, y)
+	a = got.Content[0].(*Arrow)
+	assert(a.End != arrow.End).Errorf("arrow not reconnected %v", a.End)
+}
+
+func TestParseSvg_errors(t *testing.T) {
+	assert := asserter.New(t)
+	for _, bad := range []string{
+		`<svg><g data-shape="unicorn"></g></svg>`,
+		`<svg><g data-shape="rect" data-pos="x"></g></svg>`,
+		`<svg><g data-shape="rect" data-pos="1,2" transform="spin(3)"></g></svg>`,
+		`<svg><g>`,
+	} {
+		_, err := ParseSvg(strings.NewReader(bad))
+		assert(err != nil).Errorf("expected error for %s", bad)
+	}
+}
+
+func pos(s Shape) string {
+	x, y := s.Position()
+	return fmt.Sprint(x, ",", y)
+}
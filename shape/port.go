@@ -0,0 +1,160 @@
+package shape
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/gregoryv/go-design/xy"
+)
+
+// Side of a shape
+type Side int
+
+const (
+	North Side = iota
+	East
+	South
+	West
+)
+
+func (s Side) String() string {
+	switch s {
+	case North:
+		return "N"
+	case East:
+		return "E"
+	case South:
+		return "S"
+	case West:
+		return "W"
+	}
+	return fmt.Sprintf("Side(%d)", int(s))
+}
+
+// Port is a connection point on the bounding box of a shape.
+type Port struct {
+	Side Side
+	// At is the fraction along the side, from the left of north and
+	// south sides or from the top of east and west sides.
+	At float64
+}
+
+// Ports in the middle of each side
+var (
+	N = Port{North, 0.5}
+	E = Port{East, 0.5}
+	S = Port{South, 0.5}
+	W = Port{West, 0.5}
+)
+
+// PortAt returns a port at the fraction along the given side.
+func PortAt(side Side, at float64) Port {
+	return Port{Side: side, At: at}
+}
+
+// Position returns the position of the port on shape s.
+func (p Port) Position(s Box) xy.Position {
+	x, y := s.Position()
+	w, h := s.Width(), s.Height()
+	along := func(length int) int {
+		return int(math.Round(p.At * float64(length)))
+	}
+	switch p.Side {
+	case North:
+		return xy.Position{X: x + along(w), Y: y}
+	case East:
+		return xy.Position{X: x + w, Y: y + along(h)}
+	case South:
+		return xy.Position{X: x + along(w), Y: y + h}
+	default:
+		return xy.Position{X: x, Y: y + along(h)}
+	}
+}
+
+// NewArrowPorts returns an arrow from port ap of shape a to port bp
+// of shape b.
+func NewArrowPorts(a Shape, ap Port, b Shape, bp Port) *Arrow {
+	start := ap.Position(a)
+	end := bp.Position(b)
+	arrow := NewArrow(start.X, start.Y, end.X, end.Y)
+	arrow.from, arrow.to = a, b
+	return arrow
+}
+
+// sideOf returns the side of the box closest to p.
+func sideOf(s Box, p xy.Position) Side {
+	b := boxOf(s)
+	dist := []int{
+		North: intAbs(p.Y - b.y1),
+		East:  intAbs(p.X - b.x2),
+		South: intAbs(p.Y - b.y2),
+		West:  intAbs(p.X - b.x1),
+	}
+	side := North
+	for i, d := range dist {
+		if d < dist[side] {
+			side = Side(i)
+		}
+	}
+	return side
+}
+
+// Spread distributes the ends of straight arrows created between
+// shapes evenly along each side of the shapes, so arrows to the same
+// shape do not meet in one point. Ends are ordered by the position of
+// the other shape to avoid crossings. Arrows with waypoints or routed
+// orthogonally are left as is, as moving their ends would bend them.
+func Spread(arrows ...*Arrow) {
+	type end struct {
+		arrow *Arrow
+		start bool    // the start of the arrow or the end
+		other float64 // coordinate of the other end along the side
+	}
+	type key struct {
+		b    box
+		side Side
+	}
+	var (
+		groups = make(map[key][]end)
+		keys   = make([]key, 0)
+		add    = func(k key, e end) {
+			if _, found := groups[k]; !found {
+				keys = append(keys, k)
+			}
+			groups[k] = append(groups[k], e)
+		}
+	)
+	for _, a := range arrows {
+		if a.from == nil || a.to == nil || len(a.Waypoints) > 0 || a.routed {
+			continue
+		}
+		from, to := center(a.from), center(a.to)
+		side := sideOf(a.from, a.Start)
+		add(key{boxOf(a.from), side}, end{a, true, along(side, to)})
+		side = sideOf(a.to, a.End)
+		add(key{boxOf(a.to), side}, end{a, false, along(side, from)})
+	}
+	for _, k := range keys {
+		ends := groups[k]
+		sort.SliceStable(ends, func(i, j int) bool {
+			return ends[i].other < ends[j].other
+		})
+		for i, e := range ends {
+			p := PortAt(k.side, float64(i+1)/float64(len(ends)+1))
+			if e.start {
+				e.arrow.Start = p.Position(e.arrow.from)
+			} else {
+				e.arrow.End = p.Position(e.arrow.to)
+			}
+		}
+	}
+}
+
+// along returns the coordinate of p along the given side.
+func along(side Side, p xy.Position) float64 {
+	if side == North || side == South {
+		return float64(p.X)
+	}
+	return float64(p.Y)
+}
@@ -0,0 +1,117 @@
+package shape
+
+import (
+	"testing"
+
+	"github.com/gregoryv/asserter"
+	"github.com/gregoryv/go-design/xy"
+)
+
+func TestPort_Position(t *testing.T) {
+	r := NewRect("abc")
+	r.SetX(10)
+	r.SetY(20)
+	w, h := r.Width(), r.Height()
+	assert := asserter.New(t)
+	cases := map[Port]xy.Position{
+		N:                {X: 10 + w/2, Y: 20},
+		E:                {X: 10 + w, Y: 20 + h/2},
+		S:                {X: 10 + w/2, Y: 20 + h},
+		W:                {X: 10, Y: 20 + h/2},
+		PortAt(North, 0): {X: 10, Y: 20},
+		PortAt(South, 1): {X: 10 + w, Y: 20 + h},
+		PortAt(East, 0):  {X: 10 + w, Y: 20},
+	}
+	for port, exp := range cases {
+		got := port.Position(r)
+		assert(got.Equals(exp)).Errorf("%v %v: got %v, expected %v",
+			port.Side, port.At, got, exp,
+		)
+	}
+}
+
+func TestNewArrowPorts(t *testing.T) {
+	a := NewRect("a")
+	b := NewRect("b")
+	b.SetX(100)
+	arrow := NewArrowPorts(a, S, b, N)
+	assert := asserter.New(t)
+	assert(arrow.Start.Equals(S.Position(a))).Error("start", arrow.Start)
+	assert(arrow.End.Equals(N.Position(b))).Error("end", arrow.End)
+	from, to := arrow.Between()
+	assert(from == a && to == b).Error("shapes not set")
+}
+
+func TestSpread(t *testing.T) {
+	var (
+		to     = NewRecord("target")
+		left   = NewRecord("left")
+		middle = NewRecord("middle")
+		right  = NewRecord("right")
+		svg    = newSvg(340, 200, to, left, middle, right)
+	)
+	to.SetX(120)
+	to.SetY(20)
+	for i, r := range []*Record{left, middle, right} {
+		r.SetX(10 + i*120)
+		r.SetY(140)
+	}
+	// added in mixed order
+	arrows := []*Arrow{
+		NewArrowBetween(right, to),
+		NewArrowBetween(left, to),
+		NewArrowBetween(middle, to),
+	}
+	Spread(arrows...)
+	for _, a := range arrows {
+		svg.Append(a)
+	}
+	writeSvgTo(t, "testdata/arrow_spread.svg", svg)
+
+	assert := asserter.New(t)
+	y := S.Position(to).Y
+	seen := make(map[int]bool)
+	for _, a := range arrows {
+		assert(a.End.Y == y).Errorf("%v not on south side", a.End)
+		seen[a.End.X] = true
+	}
+	assert(len(seen) == 3).Errorf("ends not spread: %v", seen)
+	// right most arrow ends right most
+	r, l := arrows[0].End.X, arrows[1].End.X
+	assert(l < r).Errorf("arrows cross, left %v right %v", l, r)
+}
+
+func TestSpread_keepsOrthogonalArrows(t *testing.T) {
+	var (
+		to  = NewRect("target")
+		a   = NewRect("a")
+		b   = NewRect("b")
+		svg = newSvg(300, 200, to, a, b)
+	)
+	a.SetWidth(to.Width())
+	to.SetX(100)
+	to.SetY(10)
+	a.SetX(100)
+	a.SetY(120)
+	b.SetX(200)
+	b.SetY(120)
+	ortho := NewArrowBetween(a, to)
+	svg.Router().Route(ortho)
+	Spread(ortho, NewArrowBetween(b, to))
+	assert := asserter.New(t)
+	assert(len(ortho.Waypoints) == 0).Fatal("expected a straight route", ortho.Points())
+	assert(ortho.Start.X == ortho.End.X).Errorf("bent %v to %v", ortho.Start, ortho.End)
+}
+
+func TestSideOf(t *testing.T) {
+	r := NewRect("x")
+	r.SetX(10)
+	r.SetY(10)
+	assert := asserter.New(t)
+	for _, side := range []Side{North, East, South, West} {
+		p := PortAt(side, 0.3).Position(r)
+		got := sideOf(r, p)
+		assert(got == side).Errorf("got %v, expected %v", got, side)
+	}
+	assert().Equals(Side(9).String(), "Side(9)")
+}
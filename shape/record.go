@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"strings"
 
 	"github.com/gregoryv/go-design/xy"
 )
@@ -19,6 +20,8 @@ func NewRecord(title string) *Record {
 }
 
 type Record struct {
+	Hyperlink
+
 	X, Y    int
 	Title   string
 	Fields  []string
@@ -26,7 +29,14 @@ type Record struct {
 
 	Font  Font
 	Pad   Padding
+	Align TextAlign // of the title, see SetTextAlign
 	class string
+
+	// embedded fields are written in a distinct style
+	embedded map[string]bool
+
+	// minimum size
+	width, height int
 }
 
 func (r *Record) String() string {
@@ -41,10 +51,13 @@ func (r *Record) SetClass(c string)    { r.class = c }
 
 func (r *Record) WriteSvg(out io.Writer) error {
 	w, err := newTagPrinter(out)
-	w.printf(
-		`<rect class="%s" x="%v" y="%v" width="%v" height="%v"/>`,
-		r.class, r.X, r.Y, r.Width(), r.Height())
-	w.printf("\n")
+	w.open("rect",
+		attr{"class", r.class},
+		attr{"x", r.X}, attr{"y", r.Y},
+		attr{"width", r.Width()}, attr{"height", r.Height()},
+	)
+	w.text("title", r.tooltipOr(r.Title))
+	w.close("rect")
 	var y = boxHeight(r.Font, r.Pad, 1) + r.Pad.Top
 	hasFields := len(r.Fields) != 0
 	if hasFields {
@@ -52,16 +65,15 @@ func (r *Record) WriteSvg(out io.Writer) error {
 		for _, txt := range r.Fields {
 			label := &Label{
 				Pos: xy.Position{
-					r.X + r.Pad.Left,
-					r.Y + y,
+					X: r.X + r.Pad.Left,
+					Y: r.Y + y,
 				},
 				Font:  r.Font,
 				Text:  txt,
-				class: "field",
+				class: r.fieldClass(txt),
 			}
 			label.WriteSvg(w)
 			y += r.Font.LineHeight
-			w.printf("\n")
 		}
 	}
 	if len(r.Methods) != 0 {
@@ -72,8 +84,8 @@ func (r *Record) WriteSvg(out io.Writer) error {
 		for _, txt := range r.Methods {
 			label := &Label{
 				Pos: xy.Position{
-					r.X + r.Pad.Left,
-					r.Y + y,
+					X: r.X + r.Pad.Left,
+					Y: r.Y + y,
 				},
 				Font:  r.Font,
 				Text:  txt,
@@ -81,13 +93,19 @@ func (r *Record) WriteSvg(out io.Writer) error {
 			}
 			label.WriteSvg(w)
 			y += r.Font.LineHeight
-			w.printf("\n")
 		}
 	}
 	r.title().WriteSvg(w)
 	return *err
 }
 
+func (r *Record) fieldClass(txt string) string {
+	if r.embedded[txt] {
+		return "embedded-field"
+	}
+	return "field"
+}
+
 func (r *Record) writeSeparator(w io.Writer, y1 int) error {
 	line := NewLine(
 		r.X, y1,
@@ -98,10 +116,17 @@ func (r *Record) writeSeparator(w io.Writer, y1 int) error {
 }
 
 func (r *Record) title() *Label {
+	var free int
+	if r.isEmpty() {
+		free = r.Height() - r.contentHeight()
+	}
+	dx, dy := r.Align.offset(
+		r.Width()-boxWidth(r.Font, r.Pad, r.Title), free,
+	)
 	return &Label{
 		Pos: xy.Position{
-			r.X + r.Pad.Left,
-			r.Y + r.Pad.Top,
+			X: r.X + r.Pad.Left + dx,
+			Y: r.Y + r.Pad.Top + dy,
 		},
 		Font:  r.Font,
 		Text:  r.Title,
@@ -115,32 +140,54 @@ func (r *Record) HideMethods() { r.Methods = []string{} }
 func (r *Record) SetFont(f Font)         { r.Font = f }
 func (r *Record) SetTextPad(pad Padding) { r.Pad = pad }
 
+// SetTextAlign aligns the title within the record. Vertical
+// alignment only applies to records without fields and methods, as
+// the title compartment of other records keeps its height. Fields
+// and methods are always left aligned.
+func (r *Record) SetTextAlign(h, v Alignment) { r.Align = TextAlign{H: h, V: v} }
+
 func (r *Record) hasFields() bool  { return len(r.Fields) != 0 }
 func (r *Record) hasMethods() bool { return len(r.Methods) != 0 }
 func (r *Record) isEmpty() bool    { return !r.hasFields() && !r.hasMethods() }
 
-func (r *Record) addFields(t reflect.Type) {
+func (r *Record) addFields(t reflect.Type, d Detail) {
 	for i := 0; i < t.NumField(); i++ {
 		field := t.Field(i)
-		if isPublic(field.Name) {
-			r.Fields = append(r.Fields, field.Name)
+		if !d.shows(field.Name) {
+			continue
+		}
+		txt := d.field(field)
+		r.Fields = append(r.Fields, txt)
+		if field.Anonymous && d.Embedded {
+			if r.embedded == nil {
+				r.embedded = make(map[string]bool)
+			}
+			r.embedded[txt] = true
 		}
 	}
 }
 
-func (rec *Record) addMethods(t reflect.Type) {
+func (rec *Record) addMethods(t reflect.Type, d Detail) {
 	for i := 0; i < t.NumMethod(); i++ {
 		m := t.Method(i)
-		if isPublic(m.Name) {
-			rec.Methods = append(rec.Methods, m.Name+"()")
+		if d.shows(m.Name) {
+			rec.Methods = append(rec.Methods, d.method(m, t))
 		}
 	}
 }
 
+// HideMethod hides method m. If m is given with a signature, e.g.
+// "Write([]byte) (int, error)", only methods with the same signature
+// are hidden, otherwise all methods named m.
 func (r *Record) HideMethod(m string) (found bool) {
+	same := func(n string) bool { return methodName(n) == methodName(m) }
+	if strings.Contains(m, "(") {
+		m = strings.TrimLeft(m, "+-")
+		same = func(n string) bool { return strings.TrimLeft(n, "+-") == m }
+	}
 	rest := make([]string, 0)
 	for _, n := range r.Methods {
-		if n == m {
+		if same(n) {
 			found = true
 			continue
 		}
@@ -156,23 +203,46 @@ func isPublic(name string) bool {
 }
 
 // NewStructRecord returns a record shape based on a Go struct type.
-// Reflection is used.
-func NewStructRecord(obj interface{}) *Record {
+// Reflection is used. Optional detail controls how fields and
+// methods are written.
+func NewStructRecord(obj interface{}, detail ...Detail) *Record {
 	t := reflect.TypeOf(obj)
-	rec := NewRecord(t.String() + " struct")
-	rec.addFields(t)
-	rec.addMethods(reflect.PtrTo(t))
+	d := firstDetail(detail)
+	rec := NewRecord(d.typeName(t) + " struct")
+	rec.addFields(t, d)
+	rec.addMethods(reflect.PtrTo(t), d)
 	return rec
 }
 
-func NewInterfaceRecord(obj interface{}) *Record {
+// NewInterfaceRecord returns a record shape based on a Go interface
+// type, obj is expected to be a nil pointer to the interface.
+func NewInterfaceRecord(obj interface{}, detail ...Detail) *Record {
 	t := reflect.TypeOf(obj).Elem()
-	rec := NewRecord(t.String() + " interface")
-	rec.addMethods(t)
+	d := firstDetail(detail)
+	rec := NewRecord(d.typeName(t) + " interface")
+	rec.addMethods(t, d)
 	return rec
 }
 
+func firstDetail(detail []Detail) Detail {
+	if len(detail) == 0 {
+		return Detail{}
+	}
+	return detail[0]
+}
+
+// SetWidth sets the minimum width of the record.
+func (r *Record) SetWidth(w int) { r.width = w }
+
+// SetHeight sets the minimum height of the record. Extra height is
+// added below the last method.
+func (r *Record) SetHeight(h int) { r.height = h }
+
 func (r *Record) Height() int {
+	return maxInt(r.height, r.contentHeight())
+}
+
+func (r *Record) contentHeight() int {
 	first := boxHeight(r.Font, r.Pad, 1)
 	if r.isEmpty() {
 		return first
@@ -186,7 +256,7 @@ func (r *Record) Height() int {
 }
 
 func (r *Record) Width() int {
-	width := boxWidth(r.Font, r.Pad, r.Title)
+	width := maxInt(r.width, boxWidth(r.Font, r.Pad, r.Title))
 	for _, txt := range r.Fields {
 		w := boxWidth(r.Font, r.Pad, txt)
 		if w > width {
@@ -18,12 +18,18 @@ func NewRect(title string) *Rect {
 }
 
 type Rect struct {
+	Hyperlink
+
 	X, Y  int
 	Title string
 
 	Font  Font
 	Pad   Padding
+	Align TextAlign
 	class string
+
+	// minimum size
+	width, height int
 }
 
 func (r *Rect) String() string {
@@ -38,19 +44,24 @@ func (r *Rect) SetClass(c string)    { r.class = c }
 
 func (r *Rect) WriteSvg(out io.Writer) error {
 	w, err := newTagPrinter(out)
-	w.printf(
-		`<rect class="%s" x="%v" y="%v" width="%v" height="%v"/>`,
-		r.class, r.X, r.Y, r.Width(), r.Height())
-	w.printf("\n")
+	w.empty("rect",
+		attr{"class", r.class},
+		attr{"x", r.X}, attr{"y", r.Y},
+		attr{"width", r.Width()}, attr{"height", r.Height()},
+	)
 	r.title().WriteSvg(w)
 	return *err
 }
 
 func (r *Rect) title() *Label {
+	dx, dy := r.Align.offset(
+		r.Width()-boxWidth(r.Font, r.Pad, r.Title),
+		r.Height()-boxHeight(r.Font, r.Pad, 1),
+	)
 	return &Label{
 		Pos: xy.Position{
-			r.X + r.Pad.Left,
-			r.Y + r.Pad.Top/2,
+			X: r.X + r.Pad.Left + dx,
+			Y: r.Y + r.Pad.Top/2 + dy,
 		},
 		Font:  r.Font,
 		Text:  r.Title,
@@ -61,14 +72,23 @@ func (r *Rect) title() *Label {
 func (r *Rect) SetFont(f Font)         { r.Font = f }
 func (r *Rect) SetTextPad(pad Padding) { r.Pad = pad }
 
+// SetTextAlign aligns the title within the rect.
+func (r *Rect) SetTextAlign(h, v Alignment) { r.Align = TextAlign{h, v} }
+
 func (r *Rect) Height() int {
-	return boxHeight(r.Font, r.Pad, 1)
+	return maxInt(r.height, boxHeight(r.Font, r.Pad, 1))
 }
 
 func (r *Rect) Width() int {
-	return boxWidth(r.Font, r.Pad, r.Title)
+	return maxInt(r.width, boxWidth(r.Font, r.Pad, r.Title))
 }
 
+// SetWidth sets the minimum width of the rect.
+func (r *Rect) SetWidth(w int) { r.width = w }
+
+// SetHeight sets the minimum height of the rect.
+func (r *Rect) SetHeight(h int) { r.height = h }
+
 // Edge returns intersecting position of a line starting at start and
 // pointing to the rect center.
 func (r *Rect) Edge(start xy.Position) xy.Position {
@@ -82,34 +102,30 @@ type Box interface {
 }
 
 func boxEdge(start xy.Position, r Box) xy.Position {
-	x, y := r.Position()
-	center := xy.Position{
-		x + r.Width()/2,
-		y + r.Height()/2,
-	}
-	l1 := xy.Line{start, center}
-
 	var (
-		d      float64 = math.MaxFloat64
-		pos    xy.Position
-		lowY   = y + r.Height()
-		rightX = x + r.Width()
-		top    = xy.NewLine(x, y, rightX, y)
-		left   = xy.NewLine(x, y, x, lowY)
-		right  = xy.NewLine(rightX, y, rightX, lowY)
-		bottom = xy.NewLine(x, lowY, rightX, lowY)
+		rect = Bounds(r)
+		from = start.Point()
+		to   = rect.Center()
+		d    = math.MaxFloat64
+		pos  xy.Point
 	)
-
-	for _, side := range []*xy.Line{top, left, right, bottom} {
-		p, err := l1.IntersectSegment(side)
-		if err != nil {
+	for _, side := range rect.Sides() {
+		p, ok := xy.IntersectSegments(from, to, side[0], side[1])
+		if !ok {
 			continue
 		}
-		dist := start.Distance(p)
-		if dist < d {
+		if dist := from.Distance(p); dist < d {
 			pos = p
 			d = dist
 		}
 	}
-	return pos
+	return pos.Position()
+}
+
+// Bounds returns the bounding rectangle of the box.
+func Bounds(b Box) xy.Box {
+	x, y := b.Position()
+	return xy.NewBox(
+		float64(x), float64(y), float64(b.Width()), float64(b.Height()),
+	)
 }
@@ -0,0 +1,324 @@
+package shape
+
+import (
+	"container/heap"
+	"sort"
+
+	"github.com/gregoryv/go-design/xy"
+)
+
+// NewRouter returns a router avoiding the given obstacles with a
+// default margin of 10 pixels.
+func NewRouter(obstacles ...Shape) *Router {
+	return &Router{
+		Obstacles: obstacles,
+		Margin:    10,
+		Bend:      40,
+	}
+}
+
+// Router finds orthogonal paths, made of horizontal and vertical
+// segments, between shapes without crossing the bounding box of
+// other shapes.
+type Router struct {
+	Obstacles []Shape
+	// Margin is the minimum distance kept to obstacles.
+	Margin int
+	// Bend is the cost of a bend compared to one pixel of length.
+	Bend int
+}
+
+// Router returns a router avoiding all shapes in the svg, including
+// children of containers, except lines and arrows.
+func (svg *Svg) Router() *Router {
+	return NewRouter(obstacles(svg.Content)...)
+}
+
+func obstacles(shapes []Shape) []Shape {
+	res := make([]Shape, 0, len(shapes))
+	for _, s := range shapes {
+		switch s := s.(type) {
+		case *Arrow, *Line:
+		case *Container:
+			res = append(res, s)
+			res = append(res, obstacles(s.Children)...)
+		default:
+			res = append(res, s)
+		}
+	}
+	return res
+}
+
+// Route replaces the path of each arrow created between two shapes
+// with an orthogonal path. Arrows for which no path is found are
+// left as is.
+func (r *Router) Route(arrows ...*Arrow) {
+	for _, arrow := range arrows {
+		if arrow.from == nil || arrow.to == nil {
+			continue
+		}
+		path := r.path(arrow.from, arrow.to)
+		if len(path) < 2 {
+			continue
+		}
+		arrow.Start = path[0]
+		arrow.End = path[len(path)-1]
+		arrow.Waypoints = path[1 : len(path)-1]
+		arrow.routed = true
+	}
+}
+
+// path returns the positions from the edge of a to the edge of b,
+// nil if none is found.
+func (r *Router) path(a, b Shape) []xy.Position {
+	var (
+		from  = boxOf(a)
+		to    = boxOf(b)
+		boxes = []box{from.grow(r.Margin), to.grow(r.Margin)}
+		xs    = make([]int, 0)
+		ys    = make([]int, 0)
+	)
+	for _, o := range r.Obstacles {
+		// shapes may not be comparable, skip by area
+		ob := boxOf(o)
+		if ob.encloses(from) || ob.encloses(to) {
+			continue // the shapes or containers around them
+		}
+		boxes = append(boxes, ob.grow(r.Margin))
+	}
+	for _, b := range boxes {
+		xs = append(xs, b.x1, b.x2)
+		ys = append(ys, b.y1, b.y2)
+	}
+	starts := from.ports(r.Margin)
+	ends := to.ports(r.Margin)
+	for _, p := range append(starts, ends...) {
+		xs = append(xs, p.exit.X)
+		ys = append(ys, p.exit.Y)
+	}
+	g := newGrid(unique(xs), unique(ys), boxes)
+	return g.search(starts, ends, r.Bend)
+}
+
+// box is a rectangle from x1,y1 to x2,y2
+type box struct {
+	x1, y1, x2, y2 int
+}
+
+func boxOf(s Box) box {
+	x, y := s.Position()
+	return box{x, y, x + s.Width(), y + s.Height()}
+}
+
+func (b box) grow(m int) box {
+	return box{b.x1 - m, b.y1 - m, b.x2 + m, b.y2 + m}
+}
+
+// encloses returns true if o is within b.
+func (b box) encloses(o box) bool {
+	return b.x1 <= o.x1 && b.y1 <= o.y1 && o.x2 <= b.x2 && o.y2 <= b.y2
+}
+
+// inside returns true if x,y is strictly inside the box.
+func (b box) inside(x, y int) bool {
+	return b.x1 < x && x < b.x2 && b.y1 < y && y < b.y2
+}
+
+// port is a connection point on the edge of a box and the position
+// just outside it, leaving in direction dir.
+type port struct {
+	edge, exit xy.Position
+	dir        int
+}
+
+// directions in which a path can move
+const (
+	dirRight = iota
+	dirDown
+	dirLeft
+	dirUp
+)
+
+var steps = [4]xy.Position{{X: 1}, {Y: 1}, {X: -1}, {Y: -1}}
+
+// ports returns the middle of each side of the box.
+func (b box) ports(margin int) []port {
+	cx, cy := (b.x1+b.x2)/2, (b.y1+b.y2)/2
+	return []port{
+		{xy.Position{X: b.x2, Y: cy}, xy.Position{X: b.x2 + margin, Y: cy}, dirRight},
+		{xy.Position{X: cx, Y: b.y2}, xy.Position{X: cx, Y: b.y2 + margin}, dirDown},
+		{xy.Position{X: b.x1, Y: cy}, xy.Position{X: b.x1 - margin, Y: cy}, dirLeft},
+		{xy.Position{X: cx, Y: b.y1}, xy.Position{X: cx, Y: b.y1 - margin}, dirUp},
+	}
+}
+
+func unique(v []int) []int {
+	sort.Ints(v)
+	res := v[:0]
+	for i, x := range v {
+		if i == 0 || x != v[i-1] {
+			res = append(res, x)
+		}
+	}
+	return res
+}
+
+// grid of possible bends, formed by all obstacle edges.
+type grid struct {
+	xs, ys []int
+	boxes  []box
+}
+
+func newGrid(xs, ys []int, boxes []box) *grid {
+	return &grid{xs: xs, ys: ys, boxes: boxes}
+}
+
+// free returns true if x,y is not inside any box.
+func (g *grid) free(x, y int) bool {
+	for _, b := range g.boxes {
+		if b.inside(x, y) {
+			return false
+		}
+	}
+	return true
+}
+
+// node is a grid position reached moving in direction dir.
+type node struct {
+	i, j, dir int
+}
+
+// search returns the cheapest path from any start port to any end
+// port, where each bend costs bend.
+func (g *grid) search(starts, ends []port, bend int) []xy.Position {
+	var (
+		index = func(p xy.Position) (int, int) {
+			return sort.SearchInts(g.xs, p.X), sort.SearchInts(g.ys, p.Y)
+		}
+		cost = make(map[node]int)
+		prev = make(map[node]node)
+		q    = &queue{}
+		goal = make(map[[2]int]port)
+	)
+	for _, p := range ends {
+		i, j := index(p.exit)
+		goal[[2]int{i, j}] = p
+	}
+	for _, p := range starts {
+		i, j := index(p.exit)
+		n := node{i, j, p.dir}
+		cost[n] = 0
+		heap.Push(q, item{n, 0})
+	}
+	var (
+		best     node
+		bestCost = -1
+	)
+	for q.Len() > 0 {
+		it := heap.Pop(q).(item)
+		n := it.n
+		if it.cost > cost[n] {
+			continue
+		}
+		if bestCost != -1 && it.cost >= bestCost {
+			break
+		}
+		if p, found := goal[[2]int{n.i, n.j}]; found {
+			// arrive moving towards the shape
+			c := it.cost
+			if n.dir != (p.dir+2)%4 {
+				c += bend
+			}
+			if bestCost == -1 || c < bestCost {
+				best, bestCost = n, c
+			}
+		}
+		for dir, step := range steps {
+			if dir == (n.dir+2)%4 {
+				continue // no turning back
+			}
+			i, j := n.i+step.X, n.j+step.Y
+			if i < 0 || j < 0 || i >= len(g.xs) || j >= len(g.ys) {
+				continue
+			}
+			x1, y1 := g.xs[n.i], g.ys[n.j]
+			x2, y2 := g.xs[i], g.ys[j]
+			if !g.free(x2, y2) || !g.free((x1+x2)/2, (y1+y2)/2) {
+				continue
+			}
+			c := it.cost + intAbs(x2-x1) + intAbs(y2-y1)
+			if dir != n.dir {
+				c += bend
+			}
+			next := node{i, j, dir}
+			if old, found := cost[next]; found && old <= c {
+				continue
+			}
+			cost[next] = c
+			prev[next] = n
+			heap.Push(q, item{next, c})
+		}
+	}
+	if bestCost == -1 {
+		return nil
+	}
+	// walk back to a start
+	path := []xy.Position{goal[[2]int{best.i, best.j}].edge}
+	n := best
+	for {
+		path = append(path, xy.Position{X: g.xs[n.i], Y: g.ys[n.j]})
+		p, found := prev[n]
+		if !found {
+			break
+		}
+		n = p
+	}
+	for _, p := range starts {
+		if i, j := index(p.exit); i == n.i && j == n.j && p.dir == n.dir {
+			path = append(path, p.edge)
+			break
+		}
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return straighten(path)
+}
+
+// straighten removes positions in the middle of straight segments.
+func straighten(path []xy.Position) []xy.Position {
+	res := make([]xy.Position, 0, len(path))
+	for i, p := range path {
+		if i > 0 && p.Equals(path[i-1]) {
+			continue
+		}
+		if n := len(res); n >= 2 {
+			a, b := res[n-2], res[n-1]
+			if (a.X == b.X && b.X == p.X) || (a.Y == b.Y && b.Y == p.Y) {
+				res[n-1] = p
+				continue
+			}
+		}
+		res = append(res, p)
+	}
+	return res
+}
+
+type item struct {
+	n    node
+	cost int
+}
+
+// queue is a priority queue of items with lowest cost first.
+type queue []item
+
+func (q queue) Len() int            { return len(q) }
+func (q queue) Less(i, j int) bool  { return q[i].cost < q[j].cost }
+func (q queue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *queue) Push(x interface{}) { *q = append(*q, x.(item)) }
+func (q *queue) Pop() interface{} {
+	old := *q
+	it := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return it
+}
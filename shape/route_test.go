@@ -0,0 +1,79 @@
+package shape
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gregoryv/asserter"
+	"github.com/gregoryv/go-design/xy"
+)
+
+func TestRouter(t *testing.T) {
+	var (
+		a     = NewRect("from")
+		b     = NewRect("to")
+		block = NewRect("obstacle in the middle")
+		svg   = newSvg(300, 200, a, b, block)
+	)
+	a.SetX(10)
+	a.SetY(80)
+	b.SetX(240)
+	b.SetY(80)
+	block.SetX(80)
+	block.SetY(70)
+	arrow := NewArrowBetween(a, b)
+	svg.Router().Route(arrow)
+	svg.Append(arrow)
+	writeSvgTo(t, "testdata/arrow_routed.svg", svg)
+
+	assert := asserter.New(t)
+	assert(len(arrow.Waypoints) > 0).Fatal("no waypoints")
+	points := arrow.Points()
+	obstacle := boxOf(block)
+	for i, p := range points[1:] {
+		q := points[i]
+		assert(p.X == q.X || p.Y == q.Y).Errorf("%v to %v not orthogonal", q, p)
+		mid := xy.Position{X: (p.X + q.X) / 2, Y: (p.Y + q.Y) / 2}
+		assert(!obstacle.inside(mid.X, mid.Y)).Errorf("%v to %v crosses obstacle", q, p)
+	}
+	assert(boxOf(a).onEdge(arrow.Start)).Errorf("start %v not on edge", arrow.Start)
+	assert(boxOf(b).onEdge(arrow.End)).Errorf("end %v not on edge", arrow.End)
+}
+
+func (b box) onEdge(p xy.Position) bool {
+	return (p.X == b.x1 || p.X == b.x2) && b.y1 <= p.Y && p.Y <= b.y2 ||
+		(p.Y == b.y1 || p.Y == b.y2) && b.x1 <= p.X && p.X <= b.x2
+}
+
+func TestRouter_Route_skipsArrowsWithoutShapes(t *testing.T) {
+	arrow := NewArrow(0, 0, 10, 10)
+	NewRouter().Route(arrow)
+	assert := asserter.New(t)
+	assert(len(arrow.Waypoints) == 0).Error("routed arrow without shapes")
+}
+
+func TestArrow_withWaypoints(t *testing.T) {
+	arrow := NewArrow(0, 0, 40, 40)
+	arrow.Tail = NewDiamond()
+	arrow.Waypoints = []xy.Position{{X: 40, Y: 0}}
+	assert := asserter.New(t)
+	assert(arrow.angle() == 90).Errorf("head angle %v", arrow.angle())
+	assert(arrow.tailAngle() == 0).Errorf("tail angle %v", arrow.tailAngle())
+	assert(arrow.Width() == 40 && arrow.Height() == 40).Error("wrong size")
+
+	buf := &bytes.Buffer{}
+	arrow.WriteSvg(buf)
+	assert().Contains(buf.String(), `d="M0,0 L40,0 L40,40"`)
+
+	arrow.SetX(10)
+	arrow.SetY(10)
+	assert(arrow.Waypoints[0].Equals(xy.Position{X: 50, Y: 10})).Error("waypoint not moved")
+}
+
+func Test_straighten(t *testing.T) {
+	got := straighten([]xy.Position{
+		{X: 0, Y: 0}, {X: 0, Y: 0}, {X: 5, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 5},
+	})
+	assert := asserter.New(t)
+	assert(len(got) == 3).Errorf("%v", got)
+}
@@ -21,6 +21,7 @@ func TestShapes(t *testing.T) {
 		NewCircle(24),
 		NewState("Waiting for push"),
 		NewDecision(),
+		NewRecord("record"),
 	}
 	for _, shape := range shapes {
 		testShape(t, shape)
@@ -91,6 +92,23 @@ func testShape(t *testing.T, shape Shape) {
 		s.SetTextPad(DefaultTextPad)
 	})
 
+	t.Run("Can be resized", func(t *testing.T) {
+		s, ok := shape.(Resizable)
+		if !ok {
+			return
+		}
+		assert := asserter.New(t)
+		w, h := shape.Width(), shape.Height()
+		s.SetWidth(w - 1)
+		s.SetHeight(h - 1)
+		assert(shape.Width() == w).Error("width smaller than content")
+		assert(shape.Height() == h).Error("height smaller than content")
+		s.SetWidth(w + 100)
+		s.SetHeight(h + 50)
+		assert(shape.Width() == w+100).Errorf("width %v", shape.Width())
+		assert(shape.Height() == h+50).Errorf("height %v", shape.Height())
+	})
+
 	t.Run("Has edge", func(t *testing.T) {
 		s, ok := shape.(Edge)
 		if !ok {
@@ -98,7 +116,47 @@ func testShape(t *testing.T, shape Shape) {
 			// everything
 			return
 		}
-		s.Edge(xy.Position{0, 0})
+		s.Edge(xy.Position{X: 0, Y: 0})
 	})
 
 }
+
+func TestTextAlign(t *testing.T) {
+	var (
+		r   = NewRect("title")
+		x0  = r.title().Pos.X
+		y0  = r.title().Pos.Y
+		svg = newSvg(300, 100, r)
+	)
+	r.SetWidth(r.Width() + 100)
+	r.SetHeight(r.Height() + 40)
+	assert := asserter.New(t)
+	assert(r.title().Pos.X == x0).Error("default not left aligned")
+
+	r.SetTextAlign(Center, Center)
+	pos := r.title().Pos
+	assert(pos.X == x0+50 && pos.Y == y0+20).Errorf("not centered %v", pos)
+
+	r.SetTextAlign(Right, Top)
+	pos = r.title().Pos
+	assert(pos.X == x0+100 && pos.Y == y0).Errorf("not right aligned %v", pos)
+	writeSvgTo(t, "testdata/text_align.svg", svg)
+
+	c := NewComponent("c")
+	cx := c.title().Pos.X
+	c.SetWidth(c.Width() + 10)
+	c.SetTextAlign(Center, Top)
+	assert(c.title().Pos.X == cx+5).Errorf("component %v", c.title().Pos)
+
+	rec := NewRecord("rec")
+	rx, ry := rec.title().Pos.XY()
+	rec.SetWidth(rec.Width() + 10)
+	rec.SetHeight(rec.Height() + 10)
+	rec.SetTextAlign(Center, Bottom)
+	pos = rec.title().Pos
+	assert(pos.X == rx+5 && pos.Y == ry+10).Errorf("record %v", pos)
+	rec.Fields = []string{"field"}
+	rec.SetHeight(rec.Height() + 10)
+	pos = rec.title().Pos
+	assert(pos.Y == ry).Errorf("title compartment of record moved %v", pos)
+}
@@ -17,12 +17,18 @@ func NewState(title string) *State {
 }
 
 type State struct {
+	Hyperlink
+
 	X, Y  int
 	Title string
 
 	Font  Font
 	Pad   Padding
+	Align TextAlign
 	class string
+
+	// minimum size
+	width, height int
 }
 
 func (r *State) String() string {
@@ -37,19 +43,26 @@ func (r *State) SetClass(c string)    { r.class = c }
 
 func (r *State) WriteSvg(out io.Writer) error {
 	w, err := newTagPrinter(out)
-	w.printf(
-		`<rect class="%s" x="%v" y="%v" width="%v" height="%v"/>`,
-		r.class, r.X, r.Y, r.Width(), r.Height())
-	w.printf("\n")
+	w.open("rect",
+		attr{"class", r.class},
+		attr{"x", r.X}, attr{"y", r.Y},
+		attr{"width", r.Width()}, attr{"height", r.Height()},
+	)
+	w.text("title", r.tooltipOr(r.Title))
+	w.close("rect")
 	r.title().WriteSvg(w)
 	return *err
 }
 
 func (r *State) title() *Label {
+	dx, dy := r.Align.offset(
+		r.Width()-boxWidth(r.Font, r.Pad, r.Title),
+		r.Height()-boxHeight(r.Font, r.Pad, 1),
+	)
 	return &Label{
 		Pos: xy.Position{
-			r.X + r.Pad.Left,
-			r.Y + r.Pad.Top/2,
+			X: r.X + r.Pad.Left + dx,
+			Y: r.Y + r.Pad.Top/2 + dy,
 		},
 		Font:  r.Font,
 		Text:  r.Title,
@@ -60,16 +73,28 @@ func (r *State) title() *Label {
 func (r *State) SetFont(f Font)         { r.Font = f }
 func (r *State) SetTextPad(pad Padding) { r.Pad = pad }
 
+// SetTextAlign aligns the title within the state.
+func (r *State) SetTextAlign(h, v Alignment) { r.Align = TextAlign{h, v} }
+
 func (r *State) Height() int {
-	return boxHeight(r.Font, r.Pad, 1)
+	return maxInt(r.height, boxHeight(r.Font, r.Pad, 1))
 }
 
 func (r *State) Width() int {
-	return boxWidth(r.Font, r.Pad, r.Title)
+	return maxInt(r.width, boxWidth(r.Font, r.Pad, r.Title))
 }
 
+// SetWidth sets the minimum width of the state.
+func (r *State) SetWidth(w int) { r.width = w }
+
+// SetHeight sets the minimum height of the state.
+func (r *State) SetHeight(h int) { r.height = h }
+
 // Edge returns intersecting position of a line starting at start and
-// pointing to the rect center.
+// pointing to the state center, following the rounded corners.
 func (r *State) Edge(start xy.Position) xy.Position {
-	return boxEdge(start, r)
+	return roundedEdge(start, r, stateRadius)
 }
+
+// stateRadius is the corner radius set by the state class
+const stateRadius = 10
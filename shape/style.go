@@ -35,39 +35,60 @@ var (
 // ClassAttributes define mapping between classes and svg attributes.
 // Setting attributes that modify size or position is not advised.
 var ClassAttributes = map[string]string{
-	"circle":                `stroke="black" stroke-width="2" fill="#ffffff"`,
-	"dot":                   `stroke="black"`,
-	"exit":                  `stroke="black" stroke-width="2" fill="#ffffff"`,
-	"exit-dot":              `stroke="black"`,
-	"note":                  `font-family="Arial,Helvetica,sans-serif"`,
-	"note-box":              `stroke="#d3d3d3" fill="#ffffcc"`,
-	"highlight":             `stroke="red"`,
-	"highlight-head":        `stroke="red" fill="#ffffff"`,
-	"implements-arrow":      `stroke="black" stroke-dasharray="5,5,5"`,
-	"implements-arrow-head": `stroke="black" fill="#ffffff"`,
-	"arrow":                 `stroke="black"`,
-	"arrow-head":            `stroke="black" fill="#ffffff"`,
-	"arrow-tail":            `stroke="black" fill="#777777"`,
-	"compose-arrow":         `stroke="black"`,
-	"compose-arrow-head":    `stroke="black" fill="#ffffff"`,
-	"compose-arrow-tail":    `stroke="black" fill="#777777"`,
-	"line":                  `stroke="black"`,
-	"column-line":           `stroke="#d3d3d3"`,
-	"record":                `stroke="#d3d3d3" fill="#ffffff"`,
-	"record-line":           `stroke="#d3d3d3"`,
-	"record-title":          `font-family="Arial,Helvetica,sans-serif"`,
-	"rect":                  `stroke="#d3d3d3" fill="#ffffff"`,
-	"state-title":           `font-family="Arial,Helvetica,sans-serif"`,
-	"state":                 `stroke="#d3d3d3" fill="#ffffff" rx="10" ry="10"`,
-	"component":             `stroke="#d3d3d3" fill="#ffffff"`,
-	"component-title":       `font-family="Arial,Helvetica,sans-serif"`,
-	"field":                 `font-family="Arial,Helvetica,sans-serif"`,
-	"method":                `font-family="Arial,Helvetica,sans-serif"`,
-	"record-label":          `font-family="Arial,Helvetica,sans-serif"`,
-	"label":                 `font-family="Arial,Helvetica,sans-serif"`,
-	"caption":               `font-family="Arial,Helvetica,sans-serif"`,
-	"diamond":               `stroke="#d3d3d3" fill="#333333"`,
-	"decision":              `stroke="#d3d3d3" fill="#ffffff"`,
+	"circle":                 `stroke="black" stroke-width="2" fill="#ffffff"`,
+	"dot":                    `stroke="black"`,
+	"exit":                   `stroke="black" stroke-width="2" fill="#ffffff"`,
+	"exit-dot":               `stroke="black"`,
+	"note":                   `font-family="Arial,Helvetica,sans-serif"`,
+	"note-box":               `stroke="#d3d3d3" fill="#ffffcc"`,
+	"highlight":              `stroke="red"`,
+	"highlight-head":         `stroke="red" fill="#ffffff"`,
+	"implements-arrow":       `stroke="black" fill="none" stroke-dasharray="5,5,5"`,
+	"implements-arrow-head":  `stroke="black" fill="#ffffff"`,
+	"arrow":                  `stroke="black" fill="none"`,
+	"arrow-head":             `stroke="black" fill="#ffffff"`,
+	"arrow-tail":             `stroke="black" fill="#777777"`,
+	"embeds-arrow":           `stroke="black" fill="none"`,
+	"embeds-arrow-head":      `stroke="black" fill="#ffffff"`,
+	"aggregate-arrow":        `stroke="black" fill="none"`,
+	"aggregate-arrow-head":   `stroke="black" fill="#ffffff"`,
+	"aggregate-arrow-tail":   `stroke="black" fill="#ffffff"`,
+	"association-arrow":      `stroke="black" fill="none"`,
+	"association-arrow-head": `stroke="black" fill="#ffffff"`,
+	"compose-arrow":          `stroke="black" fill="none"`,
+	"compose-arrow-head":     `stroke="black" fill="#ffffff"`,
+	"compose-arrow-tail":     `stroke="black" fill="#777777"`,
+	"extends-arrow":          `stroke="black" fill="none"`,
+	"extends-arrow-head":     `stroke="black" fill="#ffffff"`,
+	"uses-arrow":             `stroke="black" fill="none" stroke-dasharray="5,5,5"`,
+	"uses-arrow-head":        `stroke="black" fill="#ffffff"`,
+	"dependency-arrow":       `stroke="black" fill="none" stroke-dasharray="2,4"`,
+	"dependency-arrow-head":  `stroke="black" fill="#ffffff"`,
+	"bind-arrow":             `stroke="black" fill="none" stroke-dasharray="5,5,5"`,
+	"bind-arrow-head":        `stroke="black" fill="#ffffff"`,
+	"line":                   `stroke="black"`,
+	"column-line":            `stroke="#d3d3d3"`,
+	"record":                 `stroke="#d3d3d3" fill="#ffffff"`,
+	"record-line":            `stroke="#d3d3d3"`,
+	"record-title":           `font-family="Arial,Helvetica,sans-serif"`,
+	"rect":                   `stroke="#d3d3d3" fill="#ffffff"`,
+	"state-title":            `font-family="Arial,Helvetica,sans-serif"`,
+	"state":                  `stroke="#d3d3d3" fill="#ffffff" rx="10" ry="10"`,
+	"component":              `stroke="#d3d3d3" fill="#ffffff"`,
+	"component-title":        `font-family="Arial,Helvetica,sans-serif"`,
+	"container":              `stroke="#d3d3d3" fill="none"`,
+	"container-line":         `stroke="#d3d3d3"`,
+	"container-title":        `font-family="Arial,Helvetica,sans-serif"`,
+	"field":                  `font-family="Arial,Helvetica,sans-serif"`,
+	"embedded-field":         `font-family="Arial,Helvetica,sans-serif" font-style="italic"`,
+	"method":                 `font-family="Arial,Helvetica,sans-serif"`,
+	"record-label":           `font-family="Arial,Helvetica,sans-serif"`,
+	"label":                  `font-family="Arial,Helvetica,sans-serif"`,
+	"caption":                `font-family="Arial,Helvetica,sans-serif"`,
+	"multiplicity":           `font-family="Arial,Helvetica,sans-serif"`,
+	"role":                   `font-family="Arial,Helvetica,sans-serif" font-style="italic"`,
+	"diamond":                `stroke="#d3d3d3" fill="#333333"`,
+	"decision":               `stroke="#d3d3d3" fill="#ffffff"`,
 }
 
 // Write adds a style attribute based on class. Limited to 1 class
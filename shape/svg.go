@@ -7,20 +7,47 @@ import (
 type Svg struct {
 	Width, Height int
 	Content       []Shape
+	// Title and Desc are read by screen readers, the title is also
+	// shown as tooltip by browsers. A titled svg is labeled as a
+	// group so the titles of shapes within it are read as well.
+	Title, Desc string
+	// Indent nested elements with the given string, e.g. two
+	// spaces. Elements are not indented by default.
+	Indent string
+	// Meta embeds data-* attributes describing each shape so the
+	// written svg can be read back with ParseSvg.
+	Meta bool
 }
 
 func (shape *Svg) WriteSvg(out io.Writer) error {
 	w, err := newTagPrinter(out)
-	w.printf(`<svg
-  xmlns="http://www.w3.org/2000/svg"
-  xmlns:xlink="http://www.w3.org/1999/xlink"
-  width="%v" height="%v" font-family="Arial, Helvetica, sans-serif">`, shape.Width, shape.Height)
-
+	w.indent = shape.Indent
+	if shape.Meta {
+		w.ids = make(map[Shape]int)
+	}
+	attrs := []attr{
+		{"xmlns", "http://www.w3.org/2000/svg"},
+		{"xmlns:xlink", "http://www.w3.org/1999/xlink"},
+		{"width", shape.Width},
+		{"height", shape.Height},
+		{"font-family", "Arial, Helvetica, sans-serif"},
+	}
+	if shape.Title != "" {
+		attrs = append(attrs,
+			attr{"role", "group"}, attr{"aria-label", shape.Title},
+		)
+	}
+	w.open("svg", attrs...)
+	if shape.Title != "" {
+		w.text("title", shape.Title)
+	}
+	if shape.Desc != "" {
+		w.text("desc", shape.Desc)
+	}
 	for _, s := range shape.Content {
-		w.print("\n")
-		s.WriteSvg(w)
+		writeShape(w, s)
 	}
-	w.print("</svg>")
+	w.close("svg")
 	return *err
 }
 
@@ -1,6 +1,14 @@
 package shape
 
-import "testing"
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/gregoryv/asserter"
+)
 
 func TestOneSvg(t *testing.T) {
 	it := &OneSvg{t, &Svg{}}
@@ -10,6 +18,9 @@ func TestOneSvg(t *testing.T) {
 	// after which
 	it.AppendsShapesLastToContent()
 	it.PrependsShapeFirstToContent()
+	it.IsWellFormedWithSpecialText()
+	it.CanIndentNestedElements()
+	it.IsAccessible()
 }
 
 type OneSvg struct {
@@ -50,3 +61,80 @@ func (t *OneSvg) PrependsShapeFirstToContent() {
 		t.Error("Not first")
 	}
 }
+
+func (t *OneSvg) IsWellFormedWithSpecialText() {
+	t.Helper()
+	r := NewRecord("Tree[T any]")
+	r.Methods = []string{"Less<T>(a, b T) bool"}
+	label := NewLabel("")
+	label.Text = `say "a" & 'b'`
+	t.Content = []Shape{
+		r, label,
+		NewNote("Tom & Jerry\n<b>bold</b>"),
+		NewRect("a < b"),
+		NewContainer("x > y"),
+	}
+	var buf bytes.Buffer
+	err := t.WriteSvg(&buf)
+	assert := asserter.New(t)
+	assert(err == nil).Fatal(err)
+	dec := xml.NewDecoder(&buf)
+	texts := make([]string, 0)
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		assert(err == nil).Fatal(err)
+		if c, ok := tok.(xml.CharData); ok {
+			texts = append(texts, string(c))
+		}
+	}
+	all := strings.Join(texts, "|")
+	for _, exp := range []string{"Less<T>", `say "a" & 'b'`, "Tom & Jerry", "<b>bold</b>", "x > y"} {
+		assert(strings.Contains(all, exp)).Errorf("missing %q in %s", exp, all)
+	}
+}
+
+func (t *OneSvg) CanIndentNestedElements() {
+	t.Helper()
+	t.Indent = "  "
+	defer func() { t.Indent = "" }()
+	t.Content = []Shape{NewGroup(NewRect("a"))}
+	var buf bytes.Buffer
+	t.WriteSvg(&buf)
+	got := buf.String()
+	assert := asserter.New(t)
+	assert(strings.Contains(got, "\n  <g ")).Errorf("group not indented:\n%s", got)
+	assert(strings.Contains(got, "\n    <rect ")).Errorf("rect not indented:\n%s", got)
+}
+
+func (t *OneSvg) IsAccessible() {
+	t.Helper()
+	t.Title = "Overview"
+	t.Desc = "Records & states"
+	defer func() { t.Title, t.Desc = "", "" }()
+	t.Content = []Shape{NewRecord("Car"), NewState("Parked")}
+	var buf bytes.Buffer
+	t.WriteSvg(&buf)
+	got := buf.String()
+	assert := asserter.New(t)
+	for _, exp := range []string{
+		`role="group" aria-label="Overview">`,
+		"<title>Overview</title>",
+		"<desc>Records &amp; states</desc>",
+		"<title>Car</title>",
+		"<title>Parked</title>",
+	} {
+		assert(strings.Contains(got, exp)).Errorf("missing %s in\n%s", exp, got)
+	}
+	// title and description first for screen readers
+	i := strings.Index(got, "<desc>")
+	j := strings.Index(got, "<rect")
+	assert(i < j).Errorf("desc after shapes:\n%s", got)
+
+	t.Title = ""
+	buf.Reset()
+	t.WriteSvg(&buf)
+	assert(!strings.Contains(buf.String(), "role=")).Errorf("role without title:\n%s", buf.String())
+}
@@ -1,42 +1,131 @@
 package shape
 
 import (
+	"bytes"
 	"fmt"
 	"io"
+	"strings"
 )
 
+// newTagPrinter returns a printer writing to w. If w already is a
+// tagPrinter, e.g. when a shape writes its parts, it is returned so
+// indentation and the first error are shared.
 func newTagPrinter(w io.Writer) (*tagPrinter, *error) {
+	if tag, ok := w.(*tagPrinter); ok {
+		return tag, &tag.err
+	}
 	tag := &tagPrinter{w: w}
 	return tag, &tag.err
 }
 
-type printFunc func(...interface{})
-type printFfunc func(string, ...interface{})
-
-// tagPrinter is used to write out svg tags and if an error has
-// occured previously it's methods do nothing (the nexus).
+// tagPrinter is used to write out svg elements, one per line, and if
+// an error has occured previously it's methods do nothing (the
+// nexus). Text and attribute values are escaped.
+//
+// Each tag is written in one call to the underlying writer as Style
+// expects the entire class attribute in one write.
 type tagPrinter struct {
 	w   io.Writer
 	err error
+
+	// indent is repeated for each level of nested elements, empty
+	// for no indentation.
+	indent string
+	depth  int
+	buf    bytes.Buffer
+
+	// ids of written shapes if metadata is embedded, nil otherwise
+	ids map[Shape]int
 }
 
-func (ec *tagPrinter) printf(format string, args ...interface{}) {
-	if ec.err != nil {
-		return
-	}
-	_, ec.err = fmt.Fprintf(ec.w, format, args...)
+// attr is an attribute of an svg element, the value is formatted
+// with fmt.Sprint.
+type attr struct {
+	name  string
+	value interface{}
+}
+
+// open writes the start tag of an element containing other elements.
+func (p *tagPrinter) open(name string, attrs ...attr) {
+	p.start(name, attrs)
+	p.buf.WriteString(">")
+	p.flush()
+	p.depth++
+}
+
+// close writes the end tag of an element written with open.
+func (p *tagPrinter) close(name string) {
+	p.depth--
+	p.begin()
+	fmt.Fprintf(&p.buf, "</%s>", name)
+	p.flush()
+}
+
+// empty writes an element without content.
+func (p *tagPrinter) empty(name string, attrs ...attr) {
+	p.start(name, attrs)
+	p.buf.WriteString("/>")
+	p.flush()
+}
+
+// text writes an element with the escaped text as content.
+func (p *tagPrinter) text(name, txt string, attrs ...attr) {
+	p.start(name, attrs)
+	fmt.Fprintf(&p.buf, ">%s</%s>", escape(txt), name)
+	p.flush()
 }
 
-func (ec *tagPrinter) print(args ...interface{}) {
-	if ec.err != nil {
-		return
+func (p *tagPrinter) start(name string, attrs []attr) {
+	p.begin()
+	p.buf.WriteString("<" + name)
+	for _, a := range attrs {
+		fmt.Fprintf(&p.buf, ` %s="%s"`, a.name, escapeAttr(fmt.Sprint(a.value)))
 	}
-	_, ec.err = fmt.Fprint(ec.w, args...)
 }
 
-func (ec *tagPrinter) Write(b []byte) (int, error) {
-	if ec.err != nil {
-		return 0, ec.err
+// begin starts a new line in the buffer.
+func (p *tagPrinter) begin() {
+	p.buf.Reset()
+	p.buf.WriteString(strings.Repeat(p.indent, p.depth))
+}
+
+// flush writes the buffered line to the underlying writer.
+func (p *tagPrinter) flush() {
+	p.buf.WriteString("\n")
+	p.Write(p.buf.Bytes())
+}
+
+// Write writes b as is unless a previous write failed.
+func (p *tagPrinter) Write(b []byte) (int, error) {
+	if p.err != nil {
+		return 0, p.err
 	}
-	return ec.w.Write(b)
+	var n int
+	n, p.err = p.w.Write(b)
+	return n, p.err
+}
+
+var escaper = strings.NewReplacer(
+	"&", "&amp;",
+	"<", "&lt;",
+	">", "&gt;",
+	`"`, "&quot;",
+	"'", "&apos;",
+)
+
+// escape returns s with characters special to XML escaped.
+func escape(s string) string {
+	return escaper.Replace(s)
+}
+
+var whitespace = strings.NewReplacer(
+	"\n", "&#10;",
+	"\r", "&#13;",
+	"\t", "&#9;",
+)
+
+// escapeAttr returns the attribute value s escaped, keeping
+// whitespace which is otherwise normalized to spaces when read.
+func escapeAttr(s string) string {
+	return whitespace.Replace(escape(s))
 }
@@ -14,13 +14,39 @@ func Test_tagPrinter(t *testing.T) {
 	w, err := newTagPrinter(buf)
 	assert := asserter.New(t)
 	assert(err != nil).Error(err)
-	w.printf("ok %s\n", "printf")
-	w.print("ok print\n")
-	w.Write([]byte("ok Write\n"))
+	w.indent = "  "
+	w.open("g", attr{"class", "group"})
+	w.empty("rect", attr{"x", 1}, attr{"y", 2})
+	w.text("text", "Less<T> & more", attr{"title", `say "hi"`})
+	w.close("g")
 
 	w.err = fmt.Errorf("failed")
-	w.printf("%s should not print this", "printf")
-	w.print("print should not print this")
+	w.empty("rect", attr{"class", "should not write this"})
 	w.Write([]byte("Write should not write this"))
 	golden.Assert(t, buf.String())
 }
+
+func Test_newTagPrinter(t *testing.T) {
+	w, _ := newTagPrinter(&bytes.Buffer{})
+	nested, err := newTagPrinter(w)
+	assert := asserter.New(t)
+	assert(nested == w).Error("nested printer not shared")
+
+	w.Write([]byte("x"))
+	w.w = &failing{}
+	w.Write([]byte("x"))
+	w.w = &bytes.Buffer{}
+	w.empty("rect")
+	assert(*err != nil).Error("first error not kept")
+}
+
+type failing struct{}
+
+func (failing) Write([]byte) (int, error) { return 0, fmt.Errorf("failing") }
+
+func Test_escape(t *testing.T) {
+	got := escape(`<a href="x">Tom & 'Jerry'</a>`)
+	exp := "&lt;a href=&quot;x&quot;&gt;Tom &amp; &apos;Jerry&apos;&lt;/a&gt;"
+	assert := asserter.New(t)
+	assert(got == exp).Errorf("\ngot %s\nexp %s", got, exp)
+}
@@ -9,12 +9,14 @@ import (
 
 func NewTriangle(x, y int, class string) *Triangle {
 	return &Triangle{
-		pos:   xy.Position{x, y},
+		pos:   xy.Position{X: x, Y: y},
 		class: class,
 	}
 }
 
 type Triangle struct {
+	Hyperlink
+
 	pos   xy.Position
 	class string
 }
@@ -34,7 +36,21 @@ func (t *Triangle) SetClass(c string)    { t.class = c }
 func (tri *Triangle) WriteSvg(out io.Writer) error {
 	w, err := newTagPrinter(out)
 	// the path is drawn as if it points straight to the right
-	w.printf(`<path class="%s" d="M%v,%v l-8,-4 l 0,8 Z" />`,
-		tri.class, tri.pos.X, tri.pos.Y)
+	w.empty("path",
+		attr{"class", tri.class},
+		attr{"d", fmt.Sprintf("M%v,%v l-8,-4 l 0,8 Z", tri.pos.X, tri.pos.Y)},
+	)
 	return *err
 }
+
+// Edge returns the position where a line from start to the center of
+// the triangle crosses it, as if it points straight to the right.
+func (t *Triangle) Edge(start xy.Position) xy.Position {
+	x, y := t.pos.XY()
+	inside := xy.Position{X: x - 4, Y: y}
+	return polygonEdge(start, inside, xy.Polygon{
+		{X: x, Y: y},
+		{X: x - 8, Y: y - 4},
+		{X: x - 8, Y: y + 4},
+	})
+}
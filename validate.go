@@ -0,0 +1,214 @@
+package design
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/gregoryv/go-design/shape"
+	"github.com/gregoryv/go-design/xy"
+)
+
+// Problems found when validating a diagram, one per line.
+type Problems []string
+
+func (p Problems) Error() string {
+	return strings.Join(p, "\n")
+}
+
+// Validate returns Problems if placed shapes overlap or arrows pass
+// through labels, nil if none are found.
+func (d *Diagram) Validate() error {
+	var (
+		problems = make(Problems, 0)
+		boxes    = d.boxes()
+	)
+	for i, a := range boxes {
+		for _, b := range boxes[i+1:] {
+			if overlap(a.rect, b.rect) {
+				problems = append(problems,
+					fmt.Sprintf("%v overlaps %v", a.s, b.s),
+				)
+			}
+		}
+	}
+	for _, arrow := range d.arrows() {
+		for _, l := range d.labels() {
+			if crosses(arrow.Points(), shape.Bounds(l)) {
+				problems = append(problems,
+					fmt.Sprintf("%v crosses %v", arrow, l),
+				)
+			}
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return problems
+}
+
+// Separate moves overlapping shapes apart. Of each overlapping pair
+// the latter is moved the shortest distance, along with all shapes
+// aligned with it on that axis. Shapes aligned with each other are
+// moved along the line they share, so alignments are kept. Arrows
+// between shapes are reconnected and routed arrows routed again.
+func (d *Diagram) Separate() {
+	const gap = 10
+	for round := 0; round < 100; round++ {
+		moved := false
+		boxes := d.boxes()
+		for i, a := range boxes {
+			for _, b := range boxes[i+1:] {
+				if a.s == b.s || !overlap(a.rect, b.rect) {
+					continue
+				}
+				dx, dy := separation(a.rect, b.rect, gap)
+				// keep alignments between a and b
+				switch {
+				case aligned(a.s, b.s, false):
+					dx = 0
+				case aligned(a.s, b.s, true):
+					dy = 0
+				case math.Abs(float64(dx)) <= math.Abs(float64(dy)):
+					dy = 0
+				default:
+					dx = 0
+				}
+				for _, s := range alignedWith(b.s, a.s, boxes, dx == 0) {
+					x, y := s.Position()
+					shape.MoveTo(s, x+dx, y+dy)
+				}
+				moved = true
+				break
+			}
+			if moved {
+				break
+			}
+		}
+		if !moved {
+			break
+		}
+	}
+	d.reconnect()
+}
+
+// separation returns the shortest move of b in each direction so it
+// no longer overlaps a.
+func separation(a, b xy.Box, gap int) (dx, dy int) {
+	g := float64(gap)
+	if b.Center().X >= a.Center().X {
+		dx = int(a.BottomRight.X - b.TopLeft.X + g)
+	} else {
+		dx = -int(b.BottomRight.X - a.TopLeft.X + g)
+	}
+	if b.Center().Y >= a.Center().Y {
+		dy = int(a.BottomRight.Y - b.TopLeft.Y + g)
+	} else {
+		dy = -int(b.BottomRight.Y - a.TopLeft.Y + g)
+	}
+	return
+}
+
+// alignedWith returns s and all shapes, but other, aligned with it
+// that must move along with it. Moving vertically keeps shapes
+// sharing a top, center or bottom line, moving horizontally those
+// sharing a left, center or right line.
+func alignedWith(s, other shape.Shape, boxes []placed, vertical bool) []shape.Shape {
+	res := []shape.Shape{s}
+	for _, b := range boxes {
+		if b.s != s && b.s != other && aligned(s, b.s, vertical) {
+			res = append(res, b.s)
+		}
+	}
+	return res
+}
+
+// aligned returns true if a and b share a vertical line, i.e. left,
+// center or right, or if horizontal a top, center or bottom line.
+func aligned(a, b shape.Shape, horizontal bool) bool {
+	ra, rb := shape.Bounds(a), shape.Bounds(b)
+	if horizontal {
+		return ra.TopLeft.Y == rb.TopLeft.Y ||
+			ra.Center().Y == rb.Center().Y ||
+			ra.BottomRight.Y == rb.BottomRight.Y
+	}
+	return ra.TopLeft.X == rb.TopLeft.X ||
+		ra.Center().X == rb.Center().X ||
+		ra.BottomRight.X == rb.BottomRight.X
+}
+
+// placed is a shape and its bounding box
+type placed struct {
+	s    shape.Shape
+	rect xy.Box
+}
+
+// boxes returns shapes of the diagram that should not overlap, i.e.
+// all but arrows, lines and labels. Shapes placed more than once are
+// included once.
+func (d *Diagram) boxes() []placed {
+	res := make([]placed, 0)
+	seen := make(map[shape.Shape]bool)
+	for _, s := range d.Content {
+		switch s.(type) {
+		case *shape.Arrow, *shape.Line, *shape.Label:
+			continue
+		}
+		if seen[s] {
+			continue
+		}
+		seen[s] = true
+		res = append(res, placed{s, shape.Bounds(s)})
+	}
+	return res
+}
+
+func (d *Diagram) arrows() []*shape.Arrow {
+	return arrows(d.Content)
+}
+
+// labels returns all labels including those of arrows.
+func (d *Diagram) labels() []*shape.Label {
+	res := make([]*shape.Label, 0)
+	for _, s := range d.Content {
+		switch s := s.(type) {
+		case *shape.Label:
+			res = append(res, s)
+		case *shape.Arrow:
+			s.PlaceLabels()
+			for _, l := range s.Labels {
+				res = append(res, l.Label)
+			}
+		}
+	}
+	return res
+}
+
+// overlap returns true if a and b share an area, touching is not
+// overlapping.
+func overlap(a, b xy.Box) bool {
+	_, found := a.Intersect(b)
+	return found
+}
+
+// crosses returns true if the line through points passes through r.
+func crosses(points []xy.Position, r xy.Box) bool {
+	for i, p := range points[1:] {
+		a, b := points[i].Point(), p.Point()
+		if inside(r, a) || inside(r, b) {
+			return true
+		}
+		for _, side := range r.Sides() {
+			if _, ok := xy.IntersectSegments(a, b, side[0], side[1]); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// inside returns true if p is within r, not on its edge.
+func inside(r xy.Box, p xy.Point) bool {
+	return r.TopLeft.X < p.X && p.X < r.BottomRight.X &&
+		r.TopLeft.Y < p.Y && p.Y < r.BottomRight.Y
+}
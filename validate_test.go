@@ -0,0 +1,129 @@
+package design
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gregoryv/asserter"
+	"github.com/gregoryv/go-design/shape"
+	"github.com/gregoryv/go-design/xy"
+)
+
+func TestDiagram_Validate(t *testing.T) {
+	assert := asserter.New(t)
+	t.Run("separated shapes", func(t *testing.T) {
+		d := NewDiagram()
+		a, b := shape.NewRect("a"), shape.NewRect("b")
+		d.Place(a).At(10, 10)
+		d.Place(b).RightOf(a)
+		assert(d.Validate() == nil).Error(d.Validate())
+	})
+	t.Run("touching shapes", func(t *testing.T) {
+		d := NewDiagram()
+		a, b := shape.NewRect("a"), shape.NewRect("b")
+		d.Place(a).At(10, 10)
+		d.Place(b).At(10+a.Width(), 10)
+		assert(d.Validate() == nil).Error(d.Validate())
+	})
+	t.Run("overlapping shapes", func(t *testing.T) {
+		d := NewDiagram()
+		a, b := shape.NewRect("a"), shape.NewRect("b")
+		d.Place(a).At(10, 10)
+		d.Place(b).At(20, 20)
+		err := d.Validate()
+		assert(err != nil).Fatal("expected problems")
+		assert(strings.Contains(err.Error(), "overlaps")).Error(err)
+		assert(len(err.(Problems)) == 1).Error(err)
+	})
+	t.Run("label crossing arrow", func(t *testing.T) {
+		d := NewDiagram()
+		a, b := shape.NewRect("a"), shape.NewRect("b")
+		d.Place(a).At(10, 10)
+		d.Place(b).RightOf(a, 200)
+		d.Link(a, b, "")
+		label := shape.NewLabel("in the way")
+		d.Place(label).RightOf(a, 20)
+		label.SetY(10 + a.Height()/2 - label.Height()/2)
+		err := d.Validate()
+		assert(err != nil).Fatal("expected problems")
+		assert(strings.Contains(err.Error(), "crosses")).Error(err)
+	})
+	t.Run("arrow label", func(t *testing.T) {
+		d := NewDiagram()
+		a, b := shape.NewRect("a"), shape.NewRect("b")
+		d.Place(a).At(10, 10)
+		d.Place(b).RightOf(a, 200)
+		d.Link(a, b, "uses")
+		assert(d.Validate() == nil).Error(d.Validate())
+	})
+}
+
+func TestDiagram_Separate(t *testing.T) {
+	assert := asserter.New(t)
+	t.Run("overlapping pair", func(t *testing.T) {
+		d := NewDiagram()
+		a, b := shape.NewRect("a"), shape.NewRect("b")
+		d.Place(a).At(10, 10)
+		d.Place(b).At(20, 20)
+		d.Separate()
+		assert(d.Validate() == nil).Error(d.Validate())
+		ax, ay := a.Position()
+		assert(ax == 10 && ay == 10).Errorf("first shape moved: %v, %v", ax, ay)
+	})
+	t.Run("keeps alignment", func(t *testing.T) {
+		d := NewDiagram()
+		a, b, c := shape.NewRect("a"), shape.NewRect("b"), shape.NewRect("c")
+		d.Place(a).At(10, 10)
+		d.Place(b).At(10, 20)
+		d.Place(c).RightOf(b)
+		shape.Aligner{}.HAlignCenter(b, c)
+		d.Separate()
+		assert(d.Validate() == nil).Error(d.Validate())
+		bx, _ := b.Position()
+		assert(bx == 10).Errorf("b left the line it shares with a: %v", bx)
+		_, by := b.Position()
+		_, cy := c.Position()
+		assert(by == cy).Errorf("b and c no longer aligned: %v != %v", by, cy)
+	})
+	t.Run("reconnects arrows", func(t *testing.T) {
+		d := NewDiagram()
+		a, b := shape.NewRect("a"), shape.NewRect("b")
+		d.Place(a).At(10, 10)
+		d.Place(b).At(20, 100)
+		arrow := d.Link(a, b, "")
+		b.SetY(20)
+		d.Separate()
+		bx, by := b.Position()
+		ax, _ := a.Position()
+		assert(bx == ax+a.Width()+10 && by == 20).Errorf("b moved to %v, %v", bx, by)
+		assert(arrow.End.X == bx).Errorf("arrow not reconnected: %v, b at %v", arrow.End, bx)
+	})
+	t.Run("routes routed arrows", func(t *testing.T) {
+		d := NewDiagram()
+		a, b := shape.NewRect("a"), shape.NewRect("b")
+		d.Place(a).At(10, 10)
+		d.Place(b).At(20, 100)
+		arrow := d.LinkOrthogonal(a, b)
+		b.SetY(20)
+		d.Separate()
+		points := append([]xy.Position{arrow.Start}, arrow.Waypoints...)
+		points = append(points, arrow.End)
+		for i, p := range points[1:] {
+			q := points[i]
+			assert(p.X == q.X || p.Y == q.Y).Errorf("not orthogonal: %v", points)
+		}
+		bx, by := b.Position()
+		end := arrow.End
+		onB := end.X >= bx && end.X <= bx+b.Width() && end.Y >= by && end.Y <= by+b.Height()
+		assert(onB).Errorf("arrow not routed to b: %v, b at %v,%v", end, bx, by)
+	})
+	t.Run("placed twice", func(t *testing.T) {
+		d := NewDiagram()
+		a := shape.NewRect("a")
+		d.Place(a).At(10, 10)
+		d.Place(a).At(10, 10)
+		d.Separate()
+		ax, ay := a.Position()
+		assert(ax == 10 && ay == 10).Errorf("moved away from itself: %v, %v", ax, ay)
+	})
+}
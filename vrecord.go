@@ -2,6 +2,7 @@ package design
 
 import (
 	"fmt"
+	"go/token"
 	"reflect"
 
 	"github.com/gregoryv/go-design/shape"
@@ -12,6 +13,9 @@ type VRecord struct {
 	*shape.Record
 	t        reflect.Type
 	isStruct bool
+	embeds   bool // embedded fields are detailed
+
+	name string // of records without type, e.g. constraints
 }
 
 func (vr *VRecord) TitleOnly() {
@@ -21,14 +25,64 @@ func (vr *VRecord) TitleOnly() {
 
 // NewInterface returns a VRecord of the given object, panics if not
 // interface.
-func NewInterface(obj interface{}) VRecord {
+func NewInterface(obj interface{}, detail ...shape.Detail) VRecord {
 	t := reflect.TypeOf(obj).Elem()
 	if t.Kind() != reflect.Interface {
 		panic(fmt.Sprintf("Expected ptr kind got %v", t.Kind()))
 	}
 	return VRecord{
-		Record:   shape.NewInterfaceRecord(obj),
+		Record:   shape.NewInterfaceRecord(obj, detail...),
 		t:        t,
 		isStruct: false,
 	}
 }
+
+// methodType returns the type holding all methods of the record,
+// for structs that is the pointer type.
+func (vr *VRecord) methodType() reflect.Type {
+	if vr.isStruct {
+		return reflect.PtrTo(vr.t)
+	}
+	return vr.t
+}
+
+// NewGeneric returns a VRecord of a generic type declaration based
+// on the instantiation obj, panics if the number of type parameters
+// does not match.
+func NewGeneric(obj interface{}, params []string, detail ...shape.Detail) VRecord {
+	t := reflect.TypeOf(obj)
+	isStruct := t.Kind() == reflect.Struct
+	if !isStruct {
+		t = t.Elem()
+	}
+	return VRecord{
+		Record:   shape.NewGenericRecord(obj, params, detail...),
+		t:        t,
+		isStruct: isStruct,
+		embeds:   embedsFields(detail),
+	}
+}
+
+// embedsFields returns true if embedded fields are detailed by the
+// first of the given details.
+func embedsFields(detail []shape.Detail) bool {
+	return len(detail) > 0 && detail[0].Embedded
+}
+
+// docURL returns the documentation url of the type found at base,
+// empty for records without an exported type declared in a package.
+func (vr *VRecord) docURL(base string) string {
+	if vr.t == nil || vr.t.PkgPath() == "" || !token.IsExported(vr.typeName()) {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s#%s", base, vr.t.PkgPath(), vr.typeName())
+}
+
+// typeName returns the name of the type without package and type
+// arguments.
+func (vr *VRecord) typeName() string {
+	if vr.t == nil {
+		return vr.name
+	}
+	return shape.GenericName(vr.t)
+}
@@ -0,0 +1,81 @@
+package xy
+
+import "math"
+
+// NewBox returns a box at x, y with the given width and height.
+func NewBox(x, y, width, height float64) Box {
+	return Box{
+		TopLeft:     Point{X: x, Y: y},
+		BottomRight: Point{X: x + width, Y: y + height},
+	}
+}
+
+// Box is a rectangle with float corners, see Rect for whole pixels.
+type Box struct {
+	TopLeft     Point
+	BottomRight Point
+}
+
+func (r Box) Width() float64  { return r.BottomRight.X - r.TopLeft.X }
+func (r Box) Height() float64 { return r.BottomRight.Y - r.TopLeft.Y }
+
+// Center returns the middle of the rectangle.
+func (r Box) Center() Point {
+	return Point{
+		X: (r.TopLeft.X + r.BottomRight.X) / 2,
+		Y: (r.TopLeft.Y + r.BottomRight.Y) / 2,
+	}
+}
+
+// Empty returns true if the rectangle has no area.
+func (r Box) Empty() bool {
+	return r.Width() <= 0 || r.Height() <= 0
+}
+
+// Contains returns true if p is inside or on the edge of r.
+func (r Box) Contains(p Point) bool {
+	return r.TopLeft.X <= p.X && p.X <= r.BottomRight.X &&
+		r.TopLeft.Y <= p.Y && p.Y <= r.BottomRight.Y
+}
+
+// Union returns the smallest rectangle containing both r and s.
+func (r Box) Union(s Box) Box {
+	return Box{
+		TopLeft: Point{
+			X: math.Min(r.TopLeft.X, s.TopLeft.X),
+			Y: math.Min(r.TopLeft.Y, s.TopLeft.Y),
+		},
+		BottomRight: Point{
+			X: math.Max(r.BottomRight.X, s.BottomRight.X),
+			Y: math.Max(r.BottomRight.Y, s.BottomRight.Y),
+		},
+	}
+}
+
+// Intersect returns the area covered by both r and s, false if they
+// do not overlap.
+func (r Box) Intersect(s Box) (Box, bool) {
+	i := Box{
+		TopLeft: Point{
+			X: math.Max(r.TopLeft.X, s.TopLeft.X),
+			Y: math.Max(r.TopLeft.Y, s.TopLeft.Y),
+		},
+		BottomRight: Point{
+			X: math.Min(r.BottomRight.X, s.BottomRight.X),
+			Y: math.Min(r.BottomRight.Y, s.BottomRight.Y),
+		},
+	}
+	if i.Empty() {
+		return Box{}, false
+	}
+	return i, true
+}
+
+// Sides returns the top, right, bottom and left sides as lines
+// clockwise from the top left corner.
+func (r Box) Sides() [4][2]Point {
+	tl, br := r.TopLeft, r.BottomRight
+	tr := Point{X: br.X, Y: tl.Y}
+	bl := Point{X: tl.X, Y: br.Y}
+	return [4][2]Point{{tl, tr}, {tr, br}, {br, bl}, {bl, tl}}
+}
@@ -0,0 +1,31 @@
+package xy
+
+import "testing"
+
+func TestRect(t *testing.T) {
+	a := NewBox(0, 0, 10, 10)
+	b := NewBox(5, 5, 10, 20)
+	if a.Width() != 10 || b.Height() != 20 {
+		t.Error("size", a, b)
+	}
+	if c := a.Center(); c != (Point{X: 5, Y: 5}) {
+		t.Error("Center", c)
+	}
+	if !a.Contains(Point{X: 10, Y: 0}) || a.Contains(Point{X: 10.5, Y: 0}) {
+		t.Error("Contains")
+	}
+	if u := a.Union(b); u != NewBox(0, 0, 15, 25) {
+		t.Error("Union", u)
+	}
+	i, ok := a.Intersect(b)
+	if !ok || i != NewBox(5, 5, 5, 5) {
+		t.Error("Intersect", i, ok)
+	}
+	if _, ok := a.Intersect(NewBox(20, 20, 1, 1)); ok {
+		t.Error("Intersect outside")
+	}
+	sides := a.Sides()
+	if sides[1][0] != (Point{X: 10, Y: 0}) || sides[3][1] != a.TopLeft {
+		t.Error("Sides", sides)
+	}
+}
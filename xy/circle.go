@@ -0,0 +1,43 @@
+package xy
+
+import "math"
+
+// Circle with center and radius
+type Circle struct {
+	Center Position
+	Radius int
+}
+
+// IntersectSegment returns the positions where the line segment
+// crosses the circle, ordered by distance from the start of the line.
+func (c Circle) IntersectSegment(l *Line) []Position {
+	x1, y1 := l.Start.XYfloat64()
+	x2, y2 := l.End.XYfloat64()
+	cx, cy := c.Center.XYfloat64()
+	r := float64(c.Radius)
+	// solve |start + t(end-start) - center| = r for t
+	dx, dy := x2-x1, y2-y1
+	fx, fy := x1-cx, y1-cy
+	a := dx*dx + dy*dy
+	b := 2 * (fx*dx + fy*dy)
+	d := b*b - 4*a*(fx*fx+fy*fy-r*r)
+	res := make([]Position, 0, 2)
+	if a == 0 || d < 0 {
+		return res
+	}
+	sq := math.Sqrt(d)
+	for _, t := range []float64{(-b - sq) / (2 * a), (-b + sq) / (2 * a)} {
+		if t < 0 || t > 1 {
+			continue
+		}
+		p := Position{
+			X: int(math.Round(x1 + t*dx)),
+			Y: int(math.Round(y1 + t*dy)),
+		}
+		if len(res) == 1 && res[0].Equals(p) {
+			continue // tangent
+		}
+		res = append(res, p)
+	}
+	return res
+}
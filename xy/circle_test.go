@@ -0,0 +1,36 @@
+package xy
+
+import "testing"
+
+func TestCircle_IntersectSegment(t *testing.T) {
+	c := Circle{Center: Position{X: 10, Y: 10}, Radius: 5}
+	cases := []struct {
+		line *Line
+		exp  []Position
+	}{
+		{NewLine(0, 10, 10, 10), []Position{{5, 10}}},
+		{NewLine(0, 10, 20, 10), []Position{{5, 10}, {15, 10}}},
+		{NewLine(10, 30, 10, 10), []Position{{10, 15}}},
+		{NewLine(0, 0, 3, 3), []Position{}},
+		{NewLine(0, 15, 20, 15), []Position{{10, 15}}}, // tangent
+		{NewLine(0, 0, 0, 0), []Position{}},
+	}
+	for _, c_ := range cases {
+		got := c.IntersectSegment(c_.line)
+		if !samePositions(got, c_.exp) {
+			t.Errorf("%v: got %v, expected %v", c_.line, got, c_.exp)
+		}
+	}
+}
+
+func samePositions(a, b []Position) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equals(b[i]) {
+			return false
+		}
+	}
+	return true
+}
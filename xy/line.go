@@ -20,31 +20,41 @@ func (l1 *Line) String() string {
 // Intersect returns the position if two lines intersect.
 // https://en.wikipedia.org/wiki/Line-line_intersection
 func (l1 *Line) Intersect(l2 *Line) (Position, error) {
-	x1, y1 := l1.Start.XYfloat64()
-	x2, y2 := l1.End.XYfloat64()
-	x3, y3 := l2.Start.XYfloat64()
-	x4, y4 := l2.End.XYfloat64()
-
-	tn := (x1-x3)*(y3-y4) - (y1-y3)*(x3-x4)
-	d := (x1-x2)*(y3-y4) - (y1-y2)*(x3-x4)
-	t := tn / d
+	p, t, u, ok := intersect(
+		l1.Start.Point(), l1.End.Point(), l2.Start.Point(), l2.End.Point(),
+	)
+	if !ok || !(within(t) || within(u)) {
+		return Position{}, fmt.Errorf("Not intersecting")
+	}
+	return p.Position(), nil
+}
 
-	un := (x1-x2)*(y1-y3) - (y1-y2)*(x1-x3)
-	u := -1 * (un / d)
+// IntersectSegments returns the point where segment a1-a2 crosses
+// segment b1-b2, false if they do not cross.
+func IntersectSegments(a1, a2, b1, b2 Point) (Point, bool) {
+	p, t, u, ok := intersect(a1, a2, b1, b2)
+	return p, ok && within(t) && within(u)
+}
 
-	P := Position{}
-	switch {
-	case 0.0 <= t && t <= 1.0:
-		P.X = int(x1 + t*(x2-x1))
-		P.Y = int(y1 + t*(y2-y1))
-	case 0.0 <= u && u <= 1.0:
-		P.X = int(x3 + u*(x4-x3))
-		P.Y = int(y3 + u*(y4-y3))
-	default:
-		return P, fmt.Errorf("Not intersecting")
+// intersect returns the point where the lines through a1-a2 and
+// b1-b2 cross and the fractions t along a and u along b. ok is false
+// for parallel lines.
+func intersect(a1, a2, b1, b2 Point) (p Point, t, u float64, ok bool) {
+	d := (a1.X-a2.X)*(b1.Y-b2.Y) - (a1.Y-a2.Y)*(b1.X-b2.X)
+	if d == 0 {
+		return
 	}
+	t = ((a1.X-b1.X)*(b1.Y-b2.Y) - (a1.Y-b1.Y)*(b1.X-b2.X)) / d
+	u = -((a1.X-a2.X)*(a1.Y-b1.Y) - (a1.Y-a2.Y)*(a1.X-b1.X)) / d
+	p = a1.Add(a2.Sub(a1).Scale(t))
+	return p, t, u, true
+}
 
-	return P, nil
+// within returns true if f is in the range [0, 1] allowing for
+// rounding errors.
+func within(f float64) bool {
+	const e = 1e-9
+	return -e <= f && f <= 1+e
 }
 
 // IntersectSegment returns position where lines intersect.
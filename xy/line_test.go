@@ -86,3 +86,22 @@ func TestIntersectSegment(t *testing.T) {
 		})
 	}
 }
+
+func TestIntersectSegments(t *testing.T) {
+	p, ok := IntersectSegments(
+		Point{X: 10, Y: 10}, Point{X: 70, Y: 180},
+		Point{X: 60, Y: 180}, Point{X: 70, Y: 170},
+	)
+	if !ok || !near(p.X+p.Y, 240) {
+		t.Error("got", p, ok)
+	}
+	if got := p.Position(); !got.Equals(Position{X: 67, Y: 173}) {
+		t.Error("not rounded", got)
+	}
+	_, ok = IntersectSegments(
+		Point{}, Point{X: 1}, Point{Y: 1}, Point{X: 1, Y: 1},
+	)
+	if ok {
+		t.Error("parallel lines intersect")
+	}
+}
@@ -0,0 +1,197 @@
+package xy
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Matrix is an affine transformation in the same order as the SVG
+// matrix(a b c d e f) transform
+//
+//	x' = A*x + C*y + E
+//	y' = B*x + D*y + F
+type Matrix struct {
+	A, B, C, D, E, F float64
+}
+
+// Identity returns a matrix that transforms nothing.
+func Identity() Matrix {
+	return Matrix{A: 1, D: 1}
+}
+
+// Translate returns a matrix moving points by tx, ty.
+func Translate(tx, ty float64) Matrix {
+	return Matrix{A: 1, D: 1, E: tx, F: ty}
+}
+
+// Scale returns a matrix scaling around the origin.
+func Scale(sx, sy float64) Matrix {
+	return Matrix{A: sx, D: sy}
+}
+
+// Rotate returns a matrix rotating clockwise around the origin by
+// degrees, as the y-axis points down.
+func Rotate(degrees float64) Matrix {
+	sin, cos := math.Sincos(degrees * math.Pi / 180)
+	return Matrix{A: cos, B: sin, C: -sin, D: cos}
+}
+
+// SkewX returns a matrix skewing along the x-axis by degrees.
+func SkewX(degrees float64) Matrix {
+	return Matrix{A: 1, C: math.Tan(degrees * math.Pi / 180), D: 1}
+}
+
+// SkewY returns a matrix skewing along the y-axis by degrees.
+func SkewY(degrees float64) Matrix {
+	return Matrix{A: 1, B: math.Tan(degrees * math.Pi / 180), D: 1}
+}
+
+// Then returns a matrix applying m followed by n.
+func (m Matrix) Then(n Matrix) Matrix {
+	return Matrix{
+		A: n.A*m.A + n.C*m.B,
+		B: n.B*m.A + n.D*m.B,
+		C: n.A*m.C + n.C*m.D,
+		D: n.B*m.C + n.D*m.D,
+		E: n.A*m.E + n.C*m.F + n.E,
+		F: n.B*m.E + n.D*m.F + n.F,
+	}
+}
+
+// Apply returns p transformed by m.
+func (m Matrix) Apply(p Point) Point {
+	return Point{
+		X: m.A*p.X + m.C*p.Y + m.E,
+		Y: m.B*p.X + m.D*p.Y + m.F,
+	}
+}
+
+// Invert returns the matrix undoing m, false if m cannot be undone.
+func (m Matrix) Invert() (Matrix, bool) {
+	det := m.A*m.D - m.B*m.C
+	if det == 0 {
+		return Matrix{}, false
+	}
+	return Matrix{
+		A: m.D / det,
+		B: -m.B / det,
+		C: -m.C / det,
+		D: m.A / det,
+		E: (m.C*m.F - m.D*m.E) / det,
+		F: (m.B*m.E - m.A*m.F) / det,
+	}, true
+}
+
+// Bounds returns the smallest rectangle containing r transformed by
+// m.
+func (m Matrix) Bounds(r Box) Box {
+	corners := m.Corners(r)
+	b := Box{TopLeft: corners[0], BottomRight: corners[0]}
+	for _, c := range corners[1:] {
+		b = b.Union(Box{TopLeft: c, BottomRight: c})
+	}
+	return b
+}
+
+// Corners returns the corners of r transformed by m, clockwise from
+// the top left.
+func (m Matrix) Corners(r Box) [4]Point {
+	var res [4]Point
+	for i, side := range r.Sides() {
+		res[i] = m.Apply(side[0])
+	}
+	return res
+}
+
+// String returns the matrix as an SVG transform.
+func (m Matrix) String() string {
+	return fmt.Sprintf("matrix(%v %v %v %v %v %v)",
+		round(m.A), round(m.B), round(m.C), round(m.D), round(m.E), round(m.F),
+	)
+}
+
+// round returns f with at most four decimals.
+func round(f float64) float64 {
+	r := math.Round(f*1e4) / 1e4
+	if r == 0 {
+		return 0 // no negative zero
+	}
+	return r
+}
+
+// ParseTransform returns the matrix of an SVG transform attribute,
+// e.g. "translate(10 20) rotate(45)". Functions are applied right to
+// left as in SVG.
+func ParseTransform(s string) (Matrix, error) {
+	m := Identity()
+	rest := strings.TrimSpace(s)
+	for rest != "" {
+		open := strings.Index(rest, "(")
+		end := strings.Index(rest, ")")
+		if open == -1 || end < open {
+			return m, fmt.Errorf("malformed transform %q", s)
+		}
+		name := strings.Trim(rest[:open], " ,")
+		args, err := parseArgs(rest[open+1 : end])
+		if err != nil {
+			return m, fmt.Errorf("transform %q: %w", s, err)
+		}
+		t, err := transform(name, args)
+		if err != nil {
+			return m, fmt.Errorf("transform %q: %w", s, err)
+		}
+		m = t.Then(m)
+		rest = strings.TrimSpace(rest[end+1:])
+	}
+	return m, nil
+}
+
+// transform returns the matrix of one transform function.
+func transform(name string, a []float64) (Matrix, error) {
+	arg := func(i int, def float64) float64 {
+		if i < len(a) {
+			return a[i]
+		}
+		return def
+	}
+	if len(a) == 0 {
+		return Identity(), fmt.Errorf("%s without arguments", name)
+	}
+	switch name {
+	case "matrix":
+		if len(a) != 6 {
+			return Identity(), fmt.Errorf("matrix needs 6 arguments")
+		}
+		return Matrix{a[0], a[1], a[2], a[3], a[4], a[5]}, nil
+	case "translate":
+		return Translate(a[0], arg(1, 0)), nil
+	case "scale":
+		return Scale(a[0], arg(1, a[0])), nil
+	case "rotate":
+		cx, cy := arg(1, 0), arg(2, 0)
+		return Translate(-cx, -cy).Then(Rotate(a[0])).Then(Translate(cx, cy)), nil
+	case "skewX":
+		return SkewX(a[0]), nil
+	case "skewY":
+		return SkewY(a[0]), nil
+	}
+	return Identity(), fmt.Errorf("unknown %s", name)
+}
+
+// parseArgs returns the numbers separated by spaces or commas.
+func parseArgs(s string) ([]float64, error) {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n'
+	})
+	res := make([]float64, len(fields))
+	for i, f := range fields {
+		v, err := strconv.ParseFloat(f, 64)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = v
+	}
+	return res, nil
+}
@@ -0,0 +1,92 @@
+package xy
+
+import "testing"
+
+func TestMatrix(t *testing.T) {
+	p := Point{X: 10, Y: 0}
+	cases := []struct {
+		m   Matrix
+		exp Point
+	}{
+		{Identity(), p},
+		{Translate(5, 6), Point{X: 15, Y: 6}},
+		{Scale(2, 3), Point{X: 20, Y: 0}},
+		{Rotate(90), Point{X: 0, Y: 10}},
+		{SkewY(45), Point{X: 10, Y: 10}},
+		{SkewX(45), p},
+		{Rotate(90).Then(Translate(1, 1)), Point{X: 1, Y: 11}},
+		{Translate(1, 1).Then(Rotate(90)), Point{X: -1, Y: 11}},
+	}
+	for _, c := range cases {
+		got := c.m.Apply(p)
+		if !near(got.X, c.exp.X) || !near(got.Y, c.exp.Y) {
+			t.Errorf("%v: got %v, expected %v", c.m, got, c.exp)
+		}
+	}
+}
+
+func TestMatrix_Invert(t *testing.T) {
+	m := Rotate(30).Then(Scale(2, 3)).Then(Translate(4, 5))
+	inv, ok := m.Invert()
+	if !ok {
+		t.Fatal("not invertible")
+	}
+	p := Point{X: 7, Y: -3}
+	got := inv.Apply(m.Apply(p))
+	if !near(got.X, p.X) || !near(got.Y, p.Y) {
+		t.Error("got", got)
+	}
+	if _, ok := Scale(0, 1).Invert(); ok {
+		t.Error("inverted flat matrix")
+	}
+}
+
+func TestMatrix_Bounds(t *testing.T) {
+	r := NewBox(0, 0, 10, 20)
+	b := Rotate(90).Bounds(r)
+	if !near(b.TopLeft.X, -20) || !near(b.Width(), 20) || !near(b.Height(), 10) {
+		t.Error("got", b)
+	}
+}
+
+func TestMatrix_String(t *testing.T) {
+	got := Rotate(90).Then(Translate(1.123456, 0)).String()
+	exp := "matrix(0 1 -1 0 1.1235 0)"
+	if got != exp {
+		t.Errorf("got %q, expected %q", got, exp)
+	}
+}
+
+func TestParseTransform(t *testing.T) {
+	p := Point{X: 10, Y: 0}
+	cases := []struct {
+		txt string
+		exp Point
+	}{
+		{"", p},
+		{"translate(5)", Point{X: 15, Y: 0}},
+		{"translate(5, 6)", Point{X: 15, Y: 6}},
+		{"scale(2)", Point{X: 20, Y: 0}},
+		{"rotate(90)", Point{X: 0, Y: 10}},
+		{"rotate(90 10 10)", Point{X: 20, Y: 10}},
+		{"translate(1,1) rotate(90)", Point{X: 1, Y: 11}},
+		{"matrix(1 0 0 1 -3 4)", Point{X: 7, Y: 4}},
+		{Scale(2, 3).Then(Translate(2, 3)).String(), Point{X: 22, Y: 3}},
+	}
+	for _, c := range cases {
+		m, err := ParseTransform(c.txt)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		got := m.Apply(p)
+		if !near(got.X, c.exp.X) || !near(got.Y, c.exp.Y) {
+			t.Errorf("%q: got %v, expected %v", c.txt, got, c.exp)
+		}
+	}
+	for _, bad := range []string{"translate(", "spin(4)", "scale()", "matrix(1 2)", "scale(x)"} {
+		if _, err := ParseTransform(bad); err == nil {
+			t.Errorf("%q: expected error", bad)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package xy
+
+import (
+	"fmt"
+	"math"
+)
+
+// Point is a position with float64 coordinates.
+type Point struct {
+	X, Y float64
+}
+
+func (p Point) String() string {
+	return fmt.Sprintf("%v,%v", p.X, p.Y)
+}
+
+// Add returns p moved by v.
+func (p Point) Add(v Vector) Point {
+	return Point{X: p.X + v.X, Y: p.Y + v.Y}
+}
+
+// Sub returns the vector from q to p.
+func (p Point) Sub(q Point) Vector {
+	return Vector{X: p.X - q.X, Y: p.Y - q.Y}
+}
+
+// Distance returns the distance between p and q.
+func (p Point) Distance(q Point) float64 {
+	return p.Sub(q).Len()
+}
+
+// Position returns p rounded to the nearest int position.
+func (p Point) Position() Position {
+	return Position{X: int(math.Round(p.X)), Y: int(math.Round(p.Y))}
+}
+
+// Point returns the position as a float64 point.
+func (p Position) Point() Point {
+	return Point{X: float64(p.X), Y: float64(p.Y)}
+}
+
+// Vector is a direction and length.
+type Vector struct {
+	X, Y float64
+}
+
+func (v Vector) Add(w Vector) Vector {
+	return Vector{X: v.X + w.X, Y: v.Y + w.Y}
+}
+
+func (v Vector) Sub(w Vector) Vector {
+	return Vector{X: v.X - w.X, Y: v.Y - w.Y}
+}
+
+func (v Vector) Scale(f float64) Vector {
+	return Vector{X: v.X * f, Y: v.Y * f}
+}
+
+// Dot returns the dot product of v and w.
+func (v Vector) Dot(w Vector) float64 {
+	return v.X*w.X + v.Y*w.Y
+}
+
+// Len returns the length of v.
+func (v Vector) Len() float64 {
+	return math.Hypot(v.X, v.Y)
+}
+
+// Normalize returns v with length 1, the zero vector is returned as
+// is.
+func (v Vector) Normalize() Vector {
+	l := v.Len()
+	if l == 0 {
+		return v
+	}
+	return v.Scale(1 / l)
+}
+
+// Rotate returns v rotated clockwise by radians, as the y-axis
+// points down.
+func (v Vector) Rotate(radians float64) Vector {
+	sin, cos := math.Sincos(radians)
+	return Vector{X: v.X*cos - v.Y*sin, Y: v.X*sin + v.Y*cos}
+}
+
+// Angle returns the direction of v in degrees, clockwise from the
+// x-axis in the range (-180, 180].
+func (v Vector) Angle() float64 {
+	return math.Atan2(v.Y, v.X) * 180 / math.Pi
+}
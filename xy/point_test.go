@@ -0,0 +1,66 @@
+package xy
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPoint(t *testing.T) {
+	p := Point{X: 1.5, Y: 2}
+	q := p.Add(Vector{X: 3, Y: 4})
+	if q != (Point{X: 4.5, Y: 6}) {
+		t.Error("Add", q)
+	}
+	if v := q.Sub(p); v != (Vector{X: 3, Y: 4}) {
+		t.Error("Sub", v)
+	}
+	if d := p.Distance(q); d != 5 {
+		t.Error("Distance", d)
+	}
+	if got := p.Position(); !got.Equals(Position{X: 2, Y: 2}) {
+		t.Error("Position not rounded", got)
+	}
+	if got := (Position{X: 1, Y: 2}).Point(); got != (Point{X: 1, Y: 2}) {
+		t.Error("Point", got)
+	}
+}
+
+func TestVector(t *testing.T) {
+	v := Vector{X: 3, Y: 4}
+	if v.Len() != 5 {
+		t.Error("Len", v.Len())
+	}
+	if n := v.Normalize(); !near(n.Len(), 1) {
+		t.Error("Normalize", n)
+	}
+	if z := (Vector{}).Normalize(); z != (Vector{}) {
+		t.Error("Normalize zero", z)
+	}
+	if s := v.Scale(2).Sub(v).Add(v); s != (Vector{X: 6, Y: 8}) {
+		t.Error("Scale, Add, Sub", s)
+	}
+	if d := v.Dot(Vector{X: 1, Y: 1}); d != 7 {
+		t.Error("Dot", d)
+	}
+	// y-axis points down so rotating right by 90 points down
+	r := Vector{X: 1}.Rotate(math.Pi / 2)
+	if !near(r.X, 0) || !near(r.Y, 1) {
+		t.Error("Rotate", r)
+	}
+	cases := map[Vector]float64{
+		{X: 1}:        0,
+		{Y: 1}:        90,
+		{X: -1}:       180,
+		{Y: -1}:       -90,
+		{X: 1, Y: -1}: -45,
+	}
+	for v, exp := range cases {
+		if got := v.Angle(); !near(got, exp) {
+			t.Errorf("Angle of %v: got %v, expected %v", v, got, exp)
+		}
+	}
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
@@ -0,0 +1,40 @@
+package xy
+
+import "sort"
+
+// Polygon is a closed shape defined by its corners.
+type Polygon []Position
+
+// IntersectSegment returns the positions where the line segment
+// crosses the sides of the polygon, ordered by distance from the
+// start of the line.
+func (p Polygon) IntersectSegment(l *Line) []Position {
+	res := make([]Position, 0)
+	for i, a := range p {
+		b := p[(i+1)%len(p)]
+		hit, ok := IntersectSegments(
+			l.Start.Point(), l.End.Point(), a.Point(), b.Point(),
+		)
+		if !ok {
+			continue
+		}
+		pos := hit.Position()
+		if contains(res, pos) {
+			continue // corner shared by two sides
+		}
+		res = append(res, pos)
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		return l.Start.Distance(res[i]) < l.Start.Distance(res[j])
+	})
+	return res
+}
+
+func contains(all []Position, p Position) bool {
+	for _, q := range all {
+		if q.Equals(p) {
+			return true
+		}
+	}
+	return false
+}
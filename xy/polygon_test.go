@@ -0,0 +1,23 @@
+package xy
+
+import "testing"
+
+func TestPolygon_IntersectSegment(t *testing.T) {
+	diamond := Polygon{{0, 10}, {10, 0}, {20, 10}, {10, 20}}
+	cases := []struct {
+		line *Line
+		exp  []Position
+	}{
+		{NewLine(-10, 10, 10, 10), []Position{{0, 10}}},
+		{NewLine(30, 10, -10, 10), []Position{{20, 10}, {0, 10}}},
+		{NewLine(10, -10, 10, 10), []Position{{10, 0}}},
+		{NewLine(0, 0, 10, 10), []Position{{5, 5}}},
+		{NewLine(30, 30, 40, 40), []Position{}},
+	}
+	for _, c := range cases {
+		got := diamond.IntersectSegment(c.line)
+		if !samePositions(got, c.exp) {
+			t.Errorf("%v: got %v, expected %v", c.line, got, c.exp)
+		}
+	}
+}
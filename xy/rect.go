@@ -1,6 +1,12 @@
 package xy
 
+// Rect is a rectangle of whole pixels, see Box for float geometry.
 type Rect struct {
 	TopLeft     Position
 	BottomRight Position
 }
+
+// Box returns the rectangle with float corners.
+func (r Rect) Box() Box {
+	return Box{TopLeft: r.TopLeft.Point(), BottomRight: r.BottomRight.Point()}
+}
@@ -0,0 +1,10 @@
+package xy
+
+import "testing"
+
+func TestRect_Box(t *testing.T) {
+	r := Rect{TopLeft: Position{X: 1, Y: 2}, BottomRight: Position{X: 11, Y: 22}}
+	if b := r.Box(); b != NewBox(1, 2, 10, 20) {
+		t.Error("Box", b)
+	}
+}